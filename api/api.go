@@ -1,10 +1,31 @@
 package api
 
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
 // Response is a simple response from an HTTP service.
 // Body is assumed to be an object that is json serializable.
 type Response struct {
 	Code int
 	Body interface{}
+	// ETag is the entity tag of the response body including its quotes,
+	// e.g. "v1" or W/"v1".
+	ETag string
+	// LastModified is the time the resource was last modified.
+	LastModified time.Time
+	// Header holds the headers of the response. They are written in
+	// addition to the headers already set on the response writer.
+	Header http.Header
+}
+
+// Responder is implemented by the typed responses generated for each
+// operation in the spec. Response returns the Response which is written to
+// the client.
+type Responder interface {
+	Response() *Response
 }
 
 // Problem defines the Problem JSON type defined by RFC 7807 - media type
@@ -16,4 +37,84 @@ type Problem struct {
 	Status   int    `json:"status"`
 	Detail   string `json:"detail"`
 	Instance string `json:"instance"`
+	// InvalidParams lists the parameters and body properties which failed
+	// validation.
+	InvalidParams []InvalidParam `json:"invalid_params,omitempty"`
+	// Extensions are additional members of the problem. They are encoded
+	// next to the standard members which take precedence on conflicts.
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// InvalidParam describes a parameter or body property which failed
+// validation.
+type InvalidParam struct {
+	// Name is the name of the parameter or the path of the body property.
+	Name string `json:"name"`
+	// In is the location of the parameter: query, path, header, formData
+	// or body.
+	In string `json:"in"`
+	// Pointer is the JSON pointer to the invalid body property.
+	Pointer string `json:"pointer,omitempty"`
+	// Constraint is the validation which failed, e.g. required or pattern.
+	Constraint string `json:"constraint,omitempty"`
+	// Message describes the validation failure.
+	Message string `json:"message"`
+}
+
+// problem is used to encode the standard members of a Problem.
+type problem Problem
+
+// MarshalJSON encodes the Problem including its extension members.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(problem(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return data, err
+	}
+
+	var members map[string]json.RawMessage
+	err = json.Unmarshal(data, &members)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range p.Extensions {
+		if _, ok := members[key]; ok {
+			continue
+		}
+
+		member, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		members[key] = member
+	}
+
+	return json.Marshal(members)
+}
+
+// UnmarshalJSON decodes the Problem and collects unknown members as
+// extensions.
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	var standard problem
+	err := json.Unmarshal(data, &standard)
+	if err != nil {
+		return err
+	}
+
+	var members map[string]interface{}
+	err = json.Unmarshal(data, &members)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range []string{"type", "title", "status", "detail", "instance", "invalid_params"} {
+		delete(members, key)
+	}
+
+	if len(members) > 0 {
+		standard.Extensions = members
+	}
+
+	*p = Problem(standard)
+	return nil
 }
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestProblemJSON(t *testing.T) {
+	for _, tc := range []struct {
+		msg     string
+		problem Problem
+		json    string
+	}{
+		{
+			msg:     "standard members",
+			problem: Problem{Title: "Not Found.", Status: 404},
+			json:    `{"type":"","title":"Not Found.","status":404,"detail":"","instance":""}`,
+		},
+		{
+			msg: "extension members",
+			problem: Problem{
+				Title:      "Conflict.",
+				Status:     409,
+				Extensions: map[string]interface{}{"balance": float64(30)},
+			},
+			json: `{"balance":30,"detail":"","instance":"","status":409,"title":"Conflict.","type":""}`,
+		},
+		{
+			msg: "invalid params",
+			problem: Problem{
+				Title:         "Unprocessable Entity.",
+				Status:        422,
+				InvalidParams: []InvalidParam{{Name: "name", In: "query", Constraint: "required", Message: "name in query is required"}},
+			},
+			json: `{"type":"","title":"Unprocessable Entity.","status":422,"detail":"","instance":"","invalid_params":[{"name":"name","in":"query","constraint":"required","message":"name in query is required"}]}`,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			data, err := json.Marshal(tc.problem)
+			if err != nil {
+				t.Fatalf("should not fail: %s", err)
+			}
+
+			if string(data) != tc.json {
+				t.Errorf("expected %s, got %s", tc.json, data)
+			}
+
+			var problem Problem
+			err = json.Unmarshal(data, &problem)
+			if err != nil {
+				t.Fatalf("should not fail: %s", err)
+			}
+
+			if !reflect.DeepEqual(problem, tc.problem) {
+				t.Errorf("expected %#v, got %#v", tc.problem, problem)
+			}
+		})
+	}
+}
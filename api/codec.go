@@ -0,0 +1,277 @@
+package api
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"reflect"
+	"strings"
+	"sync"
+
+	yaml "go.yaml.in/yaml/v3"
+)
+
+// Codec encodes and decodes request and response bodies of a media type.
+type Codec interface {
+	Encode(w io.Writer, v interface{}) error
+	Decode(r io.Reader, v interface{}) error
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[string]Codec{
+		"application/json":   JSONCodec{},
+		"application/xml":    XMLCodec{},
+		"text/xml":           XMLCodec{},
+		"application/x-yaml": YAMLCodec{},
+		"application/yaml":   YAMLCodec{},
+		"text/plain":         TextCodec{},
+	}
+)
+
+// EncodeChecker is implemented by codecs which can't encode every value.
+// CanEncode returns false if the codec can't encode the value, such that
+// another media type can be negotiated for it.
+type EncodeChecker interface {
+	CanEncode(v interface{}) bool
+}
+
+// CanEncode returns true if the codec can encode the value. This is
+// assumed unless the codec implements EncodeChecker.
+func CanEncode(codec Codec, v interface{}) bool {
+	checker, ok := codec.(EncodeChecker)
+	return !ok || checker.CanEncode(v)
+}
+
+// RegisterCodec registers the codec for a media type, e.g.
+// application/msgpack. Codecs registered for the same media type are
+// replaced, including the default JSON, XML, YAML and text codecs.
+func RegisterCodec(mediaType string, codec Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[normalizeMediaType(mediaType)] = codec
+}
+
+// LookupCodec returns the codec registered for the media type. Media type
+// parameters such as charset are ignored. Media types with the +json suffix,
+// e.g. application/merge-patch+json, resolve to the application/json codec
+// unless a codec is registered for them.
+func LookupCodec(mediaType string) (Codec, bool) {
+	mediaType = normalizeMediaType(mediaType)
+
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	codec, ok := codecs[mediaType]
+	if !ok && strings.HasSuffix(mediaType, "+json") {
+		codec, ok = codecs["application/json"]
+	}
+	return codec, ok
+}
+
+// normalizeMediaType strips parameters from the media type.
+func normalizeMediaType(mediaType string) string {
+	parsed, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return mediaType
+	}
+	return parsed
+}
+
+// JSONCodec encodes and decodes application/json bodies.
+type JSONCodec struct{}
+
+// Encode writes the JSON encoding of v to w.
+func (JSONCodec) Encode(w io.Writer, v interface{}) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// Decode reads the JSON encoded value from r and stores it in v.
+func (JSONCodec) Decode(r io.Reader, v interface{}) error {
+	return json.NewDecoder(r).Decode(v)
+}
+
+// XMLCodec encodes and decodes application/xml bodies. Values containing
+// maps, which encoding/xml doesn't support, can't be encoded.
+type XMLCodec struct{}
+
+var (
+	xmlMarshalerType  = reflect.TypeOf((*xml.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// CanEncode returns true if v contains no maps, channels or functions
+// except as implementations of xml.Marshaler or encoding.TextMarshaler.
+func (XMLCodec) CanEncode(v interface{}) bool {
+	return xmlEncodable(reflect.ValueOf(v))
+}
+
+// xmlEncodable returns true if the value can be encoded by encoding/xml.
+func xmlEncodable(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+
+	t := v.Type()
+	if t.Implements(xmlMarshalerType) || t.Implements(textMarshalerType) ||
+		reflect.PointerTo(t).Implements(xmlMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return true
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil() || xmlEncodable(v.Elem())
+	case reflect.Map, reflect.Chan, reflect.Func:
+		return false
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return true
+		}
+		for i := 0; i < v.Len(); i++ {
+			if !xmlEncodable(v.Index(i)) {
+				return false
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() || field.Tag.Get("xml") == "-" {
+				continue
+			}
+			if !xmlEncodable(v.Field(i)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Encode writes the XML encoding of v to w.
+func (XMLCodec) Encode(w io.Writer, v interface{}) error {
+	return xml.NewEncoder(w).Encode(v)
+}
+
+// Decode reads the XML encoded value from r and stores it in v.
+func (XMLCodec) Decode(r io.Reader, v interface{}) error {
+	return xml.NewDecoder(r).Decode(v)
+}
+
+// YAMLCodec encodes and decodes application/x-yaml bodies. Values are
+// converted through their JSON representation, such that the json tags of
+// the models define the keys just like for application/json.
+type YAMLCodec struct{}
+
+// Encode writes the YAML encoding of v to w.
+func (YAMLCodec) Encode(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	// JSON is YAML, parsing it as a node keeps the order of the keys.
+	var node yaml.Node
+	err = yaml.Unmarshal(data, &node)
+	if err != nil {
+		return err
+	}
+	resetYAMLStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// resetYAMLStyle resets the flow and quoting style of the nodes parsed
+// from JSON to the default block style.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
+
+// Decode reads the YAML encoded value from r and stores it in v.
+func (YAMLCodec) Decode(r io.Reader, v interface{}) error {
+	var value interface{}
+	err := yaml.NewDecoder(r).Decode(&value)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// TextCodec encodes and decodes text/plain bodies. Values are encoded as
+// text if they are strings, byte slices or implement
+// encoding.TextMarshaler or fmt.Stringer. Values can be decoded into
+// strings, byte slices and encoding.TextUnmarshaler implementations.
+type TextCodec struct{}
+
+// CanEncode returns true if v can be encoded as text.
+func (TextCodec) CanEncode(v interface{}) bool {
+	switch v.(type) {
+	case string, *string, []byte, encoding.TextMarshaler, fmt.Stringer:
+		return true
+	}
+	return false
+}
+
+// Encode writes the text representation of v to w.
+func (TextCodec) Encode(w io.Writer, v interface{}) error {
+	var data []byte
+	switch value := v.(type) {
+	case string:
+		data = []byte(value)
+	case *string:
+		data = []byte(*value)
+	case []byte:
+		data = value
+	case encoding.TextMarshaler:
+		text, err := value.MarshalText()
+		if err != nil {
+			return err
+		}
+		data = text
+	case fmt.Stringer:
+		data = []byte(value.String())
+	default:
+		return fmt.Errorf("can't encode %T as text", v)
+	}
+
+	_, err := w.Write(data)
+	return err
+}
+
+// Decode reads the text from r and stores it in v.
+func (TextCodec) Decode(r io.Reader, v interface{}) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		return io.EOF
+	}
+
+	switch value := v.(type) {
+	case *string:
+		*value = string(data)
+	case *[]byte:
+		*value = data
+	case *interface{}:
+		*value = string(data)
+	case encoding.TextUnmarshaler:
+		return value.UnmarshalText(data)
+	default:
+		return fmt.Errorf("can't decode text into %T", v)
+	}
+	return nil
+}
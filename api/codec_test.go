@@ -0,0 +1,122 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+)
+
+type upperCodec struct {
+	TextCodec
+}
+
+func TestLookupCodec(t *testing.T) {
+	RegisterCodec("application/vnd.upper", upperCodec{})
+
+	for _, tc := range []struct {
+		msg       string
+		mediaType string
+		found     bool
+	}{
+		{msg: "default codec", mediaType: "application/json", found: true},
+		{msg: "media type parameters are ignored", mediaType: "text/plain; charset=utf-8", found: true},
+		{msg: "registered codec", mediaType: "application/vnd.upper", found: true},
+		{msg: "json suffix", mediaType: "application/merge-patch+json", found: true},
+		{msg: "vendor json suffix", mediaType: "application/vnd.api+json; charset=utf-8", found: true},
+		{msg: "unknown media type", mediaType: "application/msgpack", found: false},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			if _, ok := LookupCodec(tc.mediaType); ok != tc.found {
+				t.Errorf("expected codec found: %t, got %t", tc.found, ok)
+			}
+		})
+	}
+}
+
+func TestTextCodec(t *testing.T) {
+	var buf bytes.Buffer
+	err := TextCodec{}.Encode(&buf, "hello")
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	var text string
+	err = TextCodec{}.Decode(&buf, &text)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	if text != "hello" {
+		t.Errorf("expected text hello, got %s", text)
+	}
+
+	err = TextCodec{}.Decode(&buf, &text)
+	if err != io.EOF {
+		t.Errorf("expected io.EOF for empty body, got %v", err)
+	}
+
+	err = TextCodec{}.Encode(&buf, struct{}{})
+	if err == nil {
+		t.Errorf("expected error encoding a struct as text")
+	}
+}
+
+type yamlItem struct {
+	APIServerURL string   `json:"api_server_url"`
+	Count        int      `json:"count,omitempty"`
+	Version      string   `json:"version"`
+	Tags         []string `json:"tags"`
+}
+
+func TestYAMLCodec(t *testing.T) {
+	var buf bytes.Buffer
+	err := YAMLCodec{}.Encode(&buf, &yamlItem{APIServerURL: "https://example.org", Version: "1.0", Tags: []string{"a"}})
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	expected := "api_server_url: https://example.org\nversion: \"1.0\"\ntags:\n    - a\n"
+	if buf.String() != expected {
+		t.Errorf("expected YAML %q, got %q", expected, buf.String())
+	}
+
+	var item yamlItem
+	err = YAMLCodec{}.Decode(&buf, &item)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	if item.APIServerURL != "https://example.org" || item.Version != "1.0" || len(item.Tags) != 1 {
+		t.Errorf("expected decoded item, got %+v", item)
+	}
+
+	err = YAMLCodec{}.Decode(&buf, &item)
+	if err != io.EOF {
+		t.Errorf("expected io.EOF for empty body, got %v", err)
+	}
+}
+
+func TestCanEncode(t *testing.T) {
+	for _, tc := range []struct {
+		msg      string
+		codec    Codec
+		value    interface{}
+		expected bool
+	}{
+		{msg: "json", codec: JSONCodec{}, value: map[string]string{"name": "foo"}, expected: true},
+		{msg: "xml struct", codec: XMLCodec{}, value: &yamlItem{Tags: []string{"a"}}, expected: true},
+		{msg: "xml map", codec: XMLCodec{}, value: map[string]string{"name": "foo"}, expected: false},
+		{msg: "xml nested map", codec: XMLCodec{}, value: &struct{ Labels map[string]string }{}, expected: false},
+		{msg: "xml unexported map", codec: XMLCodec{}, value: &struct{ labels map[string]string }{}, expected: true},
+		{msg: "xml time", codec: XMLCodec{}, value: &struct{ Created time.Time }{}, expected: true},
+		{msg: "text string", codec: TextCodec{}, value: "hello", expected: true},
+		{msg: "text struct", codec: TextCodec{}, value: &yamlItem{}, expected: false},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			if actual := CanEncode(tc.codec, tc.value); actual != tc.expected {
+				t.Errorf("expected can encode: %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/mikkeloscar/gin-swagger/api"
+	"github.com/mikkeloscar/gin-swagger/tracing"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"golang.org/x/oauth2"
+)
+
+const (
+	problemContentType = "application/problem+json"
+)
+
+// Client sends the requests of a generated client to a gin-swagger service.
+type Client struct {
+	// BaseURL is the URL of the service, e.g. https://api.example.org.
+	BaseURL string
+	// HTTPClient is used to send the requests. http.DefaultClient is used
+	// if not set.
+	HTTPClient *http.Client
+	// TokenSource provides the OAuth2 bearer token sent with each request.
+	// Requests are sent without an Authorization header if not set.
+	TokenSource oauth2.TokenSource
+	// Tracer is used to start a client span for each request which is
+	// propagated to the service via the request headers. Tracing is
+	// disabled if not set.
+	Tracer opentracing.Tracer
+}
+
+// ResponseError is returned for responses which don't have the expected
+// status code. Problem is set if the response body is an
+// application/problem+json document.
+type ResponseError struct {
+	StatusCode int
+	Problem    *api.Problem
+	Body       []byte
+}
+
+// Error returns a string representation of the error.
+func (e *ResponseError) Error() string {
+	if e.Problem != nil {
+		if e.Problem.Detail != "" {
+			return fmt.Sprintf("unexpected response %d: %s %s", e.StatusCode, e.Problem.Title, e.Problem.Detail)
+		}
+		return fmt.Sprintf("unexpected response %d: %s", e.StatusCode, e.Problem.Title)
+	}
+	return fmt.Sprintf("unexpected response %d", e.StatusCode)
+}
+
+// Do sends the request for the operation and decodes the body of the
+// response into result if the response has the expected status code. The
+// body is decoded with DecodeBody. An expectedCode of 0 accepts any 2xx
+// status code. A nil result means the response body is discarded. Any other
+// response is returned as a *ResponseError.
+func (c *Client) Do(req *http.Request, operationID string, expectedCode int, result interface{}) error {
+	var expectedCodes []int
+	if expectedCode != 0 {
+		expectedCodes = append(expectedCodes, expectedCode)
+	}
+
+	resp, err := c.Send(req, operationID, expectedCodes...)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if result == nil {
+		return nil
+	}
+	return DecodeBody(resp, result)
+}
+
+// Send sends the request for the operation and returns the response if it
+// has one of the expected status codes, or any 2xx status code if none are
+// given. The caller must close the body of the response. Any other response
+// is returned as a *ResponseError.
+func (c *Client) Send(req *http.Request, operationID string, expectedCodes ...int) (*http.Response, error) {
+	var span opentracing.Span
+	if c.Tracer != nil {
+		var ctx context.Context
+		span, ctx = tracing.StartSpanFromContextWithTracer(req.Context(), c.Tracer, operationID)
+		defer span.Finish()
+
+		ext.SpanKindRPCClient.Set(span)
+		ext.HTTPMethod.Set(span, req.Method)
+		ext.HTTPUrl.Set(span, req.URL.String())
+
+		req = req.WithContext(ctx)
+		err := c.Tracer.Inject(span.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(req.Header))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.TokenSource != nil {
+		token, err := c.TokenSource.Token()
+		if err != nil {
+			return nil, err
+		}
+		token.SetAuthHeader(req)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if span != nil {
+		ext.HTTPStatusCode.Set(span, uint16(resp.StatusCode))
+	}
+
+	if !isExpected(resp.StatusCode, expectedCodes) {
+		defer resp.Body.Close()
+		return nil, newResponseError(resp)
+	}
+	return resp, nil
+}
+
+// DecodeBody decodes the body of the response into v with the api.Codec of
+// the response Content-Type, defaulting to JSON. Empty bodies, e.g. of 204
+// responses, leave v as is.
+func DecodeBody(resp *http.Response, v interface{}) error {
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	var codec api.Codec = api.JSONCodec{}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		if contentCodec, ok := api.LookupCodec(contentType); ok {
+			codec = contentCodec
+		}
+	}
+
+	err := codec.Decode(resp.Body, v)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// isExpected returns true if the status code is one of the expected codes
+// or is a 2xx code if no specific codes are expected.
+func isExpected(statusCode int, expectedCodes []int) bool {
+	if len(expectedCodes) == 0 {
+		return statusCode >= 200 && statusCode < 300
+	}
+
+	for _, code := range expectedCodes {
+		if statusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// newResponseError creates a ResponseError from the response.
+func newResponseError(resp *http.Response) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	respErr := &ResponseError{
+		StatusCode: resp.StatusCode,
+		Body:       body,
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == problemContentType {
+		var problem api.Problem
+		if json.Unmarshal(body, &problem) == nil {
+			respErr.Problem = &problem
+		}
+	}
+
+	return respErr
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"golang.org/x/oauth2"
+)
+
+type person struct {
+	Name string `json:"name"`
+}
+
+func TestDo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.Header().Set("Content-Type", problemContentType)
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"title": "Unauthorized.", "status": 401}`))
+			return
+		}
+
+		if r.Header.Get("Mockpfx-Ids-Traceid") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name": "john"}`))
+	}))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		msg          string
+		path         string
+		token        string
+		expectedCode int
+		statusCode   int
+		problem      bool
+	}{
+		{
+			msg:          "expected response is decoded",
+			path:         "/persons/john",
+			token:        "token",
+			expectedCode: http.StatusOK,
+		},
+		{
+			msg:          "any 2xx response is accepted",
+			path:         "/persons/john",
+			token:        "token",
+			expectedCode: 0,
+		},
+		{
+			msg:          "problem response is decoded",
+			path:         "/persons/john",
+			token:        "invalid",
+			expectedCode: http.StatusOK,
+			statusCode:   http.StatusUnauthorized,
+			problem:      true,
+		},
+		{
+			msg:          "unexpected response",
+			path:         "/missing",
+			token:        "token",
+			expectedCode: http.StatusOK,
+			statusCode:   http.StatusNotFound,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			tracer := mocktracer.New()
+			client := &Client{
+				BaseURL:     server.URL,
+				TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: tc.token}),
+				Tracer:      tracer,
+			}
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+tc.path, nil)
+			if err != nil {
+				t.Fatalf("should not fail: %s", err)
+			}
+
+			var result person
+			err = client.Do(req, "getPerson", tc.expectedCode, &result)
+
+			if len(tracer.FinishedSpans()) != 1 {
+				t.Errorf("expected one finished span, got %d", len(tracer.FinishedSpans()))
+			}
+
+			if tc.statusCode == 0 {
+				if err != nil {
+					t.Fatalf("should not fail: %s", err)
+				}
+				if result.Name != "john" {
+					t.Errorf("expected name john, got %s", result.Name)
+				}
+				return
+			}
+
+			var respErr *ResponseError
+			if !errors.As(err, &respErr) {
+				t.Fatalf("expected *ResponseError, got %v", err)
+			}
+
+			if respErr.StatusCode != tc.statusCode {
+				t.Errorf("expected status code %d, got %d", tc.statusCode, respErr.StatusCode)
+			}
+
+			if tc.problem != (respErr.Problem != nil) {
+				t.Errorf("expected problem: %t, got %v", tc.problem, respErr.Problem)
+			}
+		})
+	}
+}
+
+func TestSend(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"name": "john"}`))
+	}))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		msg           string
+		expectedCodes []int
+		valid         bool
+	}{
+		{msg: "one of the expected codes", expectedCodes: []int{http.StatusOK, http.StatusCreated}, valid: true},
+		{msg: "any 2xx code", valid: true},
+		{msg: "unexpected code", expectedCodes: []int{http.StatusOK, http.StatusNoContent}},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			client := &Client{BaseURL: server.URL}
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/persons", nil)
+			if err != nil {
+				t.Fatalf("should not fail: %s", err)
+			}
+
+			resp, err := client.Send(req, "createPerson", tc.expectedCodes...)
+			if !tc.valid {
+				var respErr *ResponseError
+				if !errors.As(err, &respErr) || respErr.StatusCode != http.StatusCreated {
+					t.Errorf("expected *ResponseError with status code %d, got %v", http.StatusCreated, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("should not fail: %s", err)
+			}
+			defer resp.Body.Close()
+
+			var result person
+			err = DecodeBody(resp, &result)
+			if err != nil {
+				t.Fatalf("should not fail: %s", err)
+			}
+
+			if result.Name != "john" {
+				t.Errorf("expected name john, got %s", result.Name)
+			}
+		})
+	}
+}
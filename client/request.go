@@ -0,0 +1,68 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"reflect"
+	"strconv"
+
+	"github.com/go-openapi/swag/stringutils"
+	"github.com/mikkeloscar/gin-swagger/api"
+)
+
+// FormatValue formats a parameter value for use in the path, query or
+// headers of a request.
+func FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// FormatValues formats the items of an array parameter and joins them
+// according to the collection format of the parameter. For the multi format
+// each item is returned as a separate value.
+func FormatValues(values interface{}, collectionFormat string) []string {
+	v := reflect.ValueOf(values)
+	if v.Kind() != reflect.Slice {
+		return nil
+	}
+
+	items := make([]string, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		items = append(items, FormatValue(v.Index(i).Interface()))
+	}
+
+	return stringutils.JoinByFormat(items, collectionFormat)
+}
+
+// WriteFormFile writes the content of a file parameter as a part of the
+// multipart form.
+func WriteFormFile(form *multipart.Writer, name string, file io.Reader) error {
+	part, err := form.CreateFormFile(name, name)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(part, file)
+	return err
+}
+
+// EncodeBody encodes the body of a request with the api.Codec registered
+// for the media type.
+func EncodeBody(w io.Writer, mediaType string, body interface{}) error {
+	codec, ok := api.LookupCodec(mediaType)
+	if !ok {
+		return fmt.Errorf("no codec registered for media type '%s'", mediaType)
+	}
+	return codec.Encode(w, body)
+}
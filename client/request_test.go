@@ -0,0 +1,49 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+)
+
+func TestFormatValue(t *testing.T) {
+	for _, tc := range []struct {
+		msg      string
+		value    interface{}
+		expected string
+	}{
+		{msg: "string", value: "foo", expected: "foo"},
+		{msg: "bool", value: true, expected: "true"},
+		{msg: "integer", value: int32(42), expected: "42"},
+		{msg: "float", value: 1.5, expected: "1.5"},
+		{msg: "stringer", value: strfmt.UUID("a-b"), expected: "a-b"},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			if value := FormatValue(tc.value); value != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, value)
+			}
+		})
+	}
+}
+
+func TestFormatValues(t *testing.T) {
+	for _, tc := range []struct {
+		msg              string
+		values           interface{}
+		collectionFormat string
+		expected         []string
+	}{
+		{msg: "csv", values: []int64{1, 2}, collectionFormat: "csv", expected: []string{"1,2"}},
+		{msg: "default", values: []string{"a", "b"}, expected: []string{"a,b"}},
+		{msg: "multi", values: []string{"a", "b"}, collectionFormat: "multi", expected: []string{"a", "b"}},
+		{msg: "not a slice", values: "a", expected: nil},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			values := FormatValues(tc.values, tc.collectionFormat)
+			if !reflect.DeepEqual(values, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, values)
+			}
+		})
+	}
+}
@@ -0,0 +1,403 @@
+// Code generated by gin-swagger; DO NOT EDIT.
+
+package client
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"fmt"
+
+	ginclient "github.com/mikkeloscar/gin-swagger/client"
+
+	"github.com/mikkeloscar/gin-swagger/example/restapi/operations/clusters"
+	"github.com/mikkeloscar/gin-swagger/example/restapi/operations/config_items"
+	"github.com/mikkeloscar/gin-swagger/example/restapi/operations/infrastructure_accounts"
+	"github.com/mikkeloscar/gin-swagger/example/restapi/operations/node_pools"
+)
+
+// Client is a client for the Example service. Set TokenSource and
+// Tracer of the embedded ginclient.Client to authenticate the requests and
+// to propagate tracing spans to the service.
+type Client struct {
+	*ginclient.Client
+}
+
+// New creates a Client for the service running at baseURL.
+func New(baseURL string) *Client {
+	return &Client{
+		Client: &ginclient.Client{
+			BaseURL: baseURL,
+		},
+	}
+}
+
+// AddOrUpdateConfigItem calls the add or update config item operation.
+// Responses other than 200 are returned as a *ginclient.ResponseError.
+func (c *Client) AddOrUpdateConfigItem(ctx context.Context, params *config_items.AddOrUpdateConfigItemParams) (*config_items.AddOrUpdateConfigItemOK, error) {
+	req, err := config_items.NewAddOrUpdateConfigItemRequest(ctx, c.BaseURL, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Send(req, "addOrUpdateConfigItem", 200)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &config_items.AddOrUpdateConfigItemOK{}
+	err = result.ReadHeader(resp.Header)
+	if err != nil {
+		return nil, err
+	}
+	err = ginclient.DecodeBody(resp, &result.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateCluster calls the create cluster operation.
+// Responses other than 201 are returned as a *ginclient.ResponseError.
+func (c *Client) CreateCluster(ctx context.Context, params *clusters.CreateClusterParams) (*clusters.CreateClusterCreated, error) {
+	req, err := clusters.NewCreateClusterRequest(ctx, c.BaseURL, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Send(req, "createCluster", 201)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &clusters.CreateClusterCreated{}
+	err = result.ReadHeader(resp.Header)
+	if err != nil {
+		return nil, err
+	}
+	err = ginclient.DecodeBody(resp, &result.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateInfrastructureAccount calls the create infrastructure account operation.
+// Responses other than 201 are returned as a *ginclient.ResponseError.
+func (c *Client) CreateInfrastructureAccount(ctx context.Context, params *infrastructure_accounts.CreateInfrastructureAccountParams) (*infrastructure_accounts.CreateInfrastructureAccountCreated, error) {
+	req, err := infrastructure_accounts.NewCreateInfrastructureAccountRequest(ctx, c.BaseURL, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Send(req, "createInfrastructureAccount", 201)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &infrastructure_accounts.CreateInfrastructureAccountCreated{}
+	err = result.ReadHeader(resp.Header)
+	if err != nil {
+		return nil, err
+	}
+	err = ginclient.DecodeBody(resp, &result.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateOrUpdateNodePool calls the create or update node pool operation.
+// Responses other than 200, 201 are returned as a *ginclient.ResponseError.
+// The success responses are returned as the node_pools.CreateOrUpdateNodePoolResponder
+// of their status code, e.g. *node_pools.CreateOrUpdateNodePoolOK.
+func (c *Client) CreateOrUpdateNodePool(ctx context.Context, params *node_pools.CreateOrUpdateNodePoolParams) (node_pools.CreateOrUpdateNodePoolResponder, error) {
+	req, err := node_pools.NewCreateOrUpdateNodePoolRequest(ctx, c.BaseURL, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Send(req, "createOrUpdateNodePool", 200, 201)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200:
+		result := &node_pools.CreateOrUpdateNodePoolOK{}
+		err = result.ReadHeader(resp.Header)
+		if err != nil {
+			return nil, err
+		}
+		err = ginclient.DecodeBody(resp, &result.Payload)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 201:
+		result := &node_pools.CreateOrUpdateNodePoolCreated{}
+		err = result.ReadHeader(resp.Header)
+		if err != nil {
+			return nil, err
+		}
+		err = ginclient.DecodeBody(resp, &result.Payload)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+}
+
+// DeleteCluster calls the delete cluster operation.
+// Responses other than 204 are returned as a *ginclient.ResponseError.
+func (c *Client) DeleteCluster(ctx context.Context, params *clusters.DeleteClusterParams) (*clusters.DeleteClusterNoContent, error) {
+	req, err := clusters.NewDeleteClusterRequest(ctx, c.BaseURL, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Send(req, "deleteCluster", 204)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &clusters.DeleteClusterNoContent{}
+	err = result.ReadHeader(resp.Header)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteConfigItem calls the delete config item operation.
+// Responses other than 204 are returned as a *ginclient.ResponseError.
+func (c *Client) DeleteConfigItem(ctx context.Context, params *config_items.DeleteConfigItemParams) (*config_items.DeleteConfigItemNoContent, error) {
+	req, err := config_items.NewDeleteConfigItemRequest(ctx, c.BaseURL, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Send(req, "deleteConfigItem", 204)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &config_items.DeleteConfigItemNoContent{}
+	err = result.ReadHeader(resp.Header)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteNodePool calls the delete node pool operation.
+// Responses other than 204 are returned as a *ginclient.ResponseError.
+func (c *Client) DeleteNodePool(ctx context.Context, params *node_pools.DeleteNodePoolParams) (*node_pools.DeleteNodePoolNoContent, error) {
+	req, err := node_pools.NewDeleteNodePoolRequest(ctx, c.BaseURL, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Send(req, "deleteNodePool", 204)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &node_pools.DeleteNodePoolNoContent{}
+	err = result.ReadHeader(resp.Header)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetCluster calls the get cluster operation.
+// Responses other than 200 are returned as a *ginclient.ResponseError.
+func (c *Client) GetCluster(ctx context.Context, params *clusters.GetClusterParams) (*clusters.GetClusterOK, error) {
+	req, err := clusters.NewGetClusterRequest(ctx, c.BaseURL, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Send(req, "getCluster", 200)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &clusters.GetClusterOK{}
+	err = result.ReadHeader(resp.Header)
+	if err != nil {
+		return nil, err
+	}
+	err = ginclient.DecodeBody(resp, &result.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetInfrastructureAccount calls the get infrastructure account operation.
+// Responses other than 200 are returned as a *ginclient.ResponseError.
+func (c *Client) GetInfrastructureAccount(ctx context.Context, params *infrastructure_accounts.GetInfrastructureAccountParams) (*infrastructure_accounts.GetInfrastructureAccountOK, error) {
+	req, err := infrastructure_accounts.NewGetInfrastructureAccountRequest(ctx, c.BaseURL, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Send(req, "getInfrastructureAccount", 200)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &infrastructure_accounts.GetInfrastructureAccountOK{}
+	err = result.ReadHeader(resp.Header)
+	if err != nil {
+		return nil, err
+	}
+	err = ginclient.DecodeBody(resp, &result.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListClusters calls the list clusters operation.
+// Responses other than 200 are returned as a *ginclient.ResponseError.
+func (c *Client) ListClusters(ctx context.Context, params *clusters.ListClustersParams) (*clusters.ListClustersOK, error) {
+	req, err := clusters.NewListClustersRequest(ctx, c.BaseURL, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Send(req, "listClusters", 200)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &clusters.ListClustersOK{}
+	err = result.ReadHeader(resp.Header)
+	if err != nil {
+		return nil, err
+	}
+	err = ginclient.DecodeBody(resp, &result.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListInfrastructureAccounts calls the list infrastructure accounts operation.
+// Responses other than 200 are returned as a *ginclient.ResponseError.
+func (c *Client) ListInfrastructureAccounts(ctx context.Context) (*infrastructure_accounts.ListInfrastructureAccountsOK, error) {
+	req, err := infrastructure_accounts.NewListInfrastructureAccountsRequest(ctx, c.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Send(req, "listInfrastructureAccounts", 200)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &infrastructure_accounts.ListInfrastructureAccountsOK{}
+	err = result.ReadHeader(resp.Header)
+	if err != nil {
+		return nil, err
+	}
+	err = ginclient.DecodeBody(resp, &result.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListNodePools calls the list node pools operation.
+// Responses other than 200 are returned as a *ginclient.ResponseError.
+func (c *Client) ListNodePools(ctx context.Context, params *node_pools.ListNodePoolsParams) (*node_pools.ListNodePoolsOK, error) {
+	req, err := node_pools.NewListNodePoolsRequest(ctx, c.BaseURL, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Send(req, "listNodePools", 200)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &node_pools.ListNodePoolsOK{}
+	err = result.ReadHeader(resp.Header)
+	if err != nil {
+		return nil, err
+	}
+	err = ginclient.DecodeBody(resp, &result.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateCluster calls the update cluster operation.
+// Responses other than 200 are returned as a *ginclient.ResponseError.
+func (c *Client) UpdateCluster(ctx context.Context, params *clusters.UpdateClusterParams) (*clusters.UpdateClusterOK, error) {
+	req, err := clusters.NewUpdateClusterRequest(ctx, c.BaseURL, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Send(req, "updateCluster", 200)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &clusters.UpdateClusterOK{}
+	err = result.ReadHeader(resp.Header)
+	if err != nil {
+		return nil, err
+	}
+	err = ginclient.DecodeBody(resp, &result.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateInfrastructureAccount calls the update infrastructure account operation.
+// Responses other than 200 are returned as a *ginclient.ResponseError.
+func (c *Client) UpdateInfrastructureAccount(ctx context.Context, params *infrastructure_accounts.UpdateInfrastructureAccountParams) (*infrastructure_accounts.UpdateInfrastructureAccountOK, error) {
+	req, err := infrastructure_accounts.NewUpdateInfrastructureAccountRequest(ctx, c.BaseURL, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Send(req, "updateInfrastructureAccount", 200)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &infrastructure_accounts.UpdateInfrastructureAccountOK{}
+	err = result.ReadHeader(resp.Header)
+	if err != nil {
+		return nil, err
+	}
+	err = ginclient.DecodeBody(resp, &result.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
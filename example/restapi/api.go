@@ -7,23 +7,23 @@ package restapi
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
-	"golang.org/x/oauth2"
-
 	"github.com/gin-contrib/pprof"
 	"github.com/gin-gonic/gin"
-	"github.com/mikkeloscar/gin-swagger/api"
+	"github.com/mikkeloscar/gin-swagger/metrics"
 	"github.com/mikkeloscar/gin-swagger/middleware"
+	"github.com/mikkeloscar/gin-swagger/oteltracing"
 	"github.com/mikkeloscar/gin-swagger/tracing"
-	opentracing "github.com/opentracing/opentracing-go"
 	log "github.com/sirupsen/logrus"
-	ginoauth2 "github.com/zalando/gin-oauth2"
 
 	"github.com/mikkeloscar/gin-swagger/example/restapi/operations/clusters"
 	"github.com/mikkeloscar/gin-swagger/example/restapi/operations/config_items"
@@ -138,316 +138,498 @@ func healthHandler(healthFunc func() bool) gin.HandlerFunc {
 // business logic for the Server service.
 type Service interface {
 	Healthy() bool
-	AddOrUpdateConfigItem(ctx *gin.Context, params *config_items.AddOrUpdateConfigItemParams) *api.Response
-	CreateCluster(ctx *gin.Context, params *clusters.CreateClusterParams) *api.Response
-	CreateInfrastructureAccount(ctx *gin.Context, params *infrastructure_accounts.CreateInfrastructureAccountParams) *api.Response
-	CreateOrUpdateNodePool(ctx *gin.Context, params *node_pools.CreateOrUpdateNodePoolParams) *api.Response
-	DeleteCluster(ctx *gin.Context, params *clusters.DeleteClusterParams) *api.Response
-	DeleteConfigItem(ctx *gin.Context, params *config_items.DeleteConfigItemParams) *api.Response
-	DeleteNodePool(ctx *gin.Context, params *node_pools.DeleteNodePoolParams) *api.Response
-	GetCluster(ctx *gin.Context, params *clusters.GetClusterParams) *api.Response
-	GetInfrastructureAccount(ctx *gin.Context, params *infrastructure_accounts.GetInfrastructureAccountParams) *api.Response
-	ListClusters(ctx *gin.Context, params *clusters.ListClustersParams) *api.Response
-	ListInfrastructureAccounts(ctx *gin.Context) *api.Response
-	ListNodePools(ctx *gin.Context, params *node_pools.ListNodePoolsParams) *api.Response
-	UpdateCluster(ctx *gin.Context, params *clusters.UpdateClusterParams) *api.Response
-	UpdateInfrastructureAccount(ctx *gin.Context, params *infrastructure_accounts.UpdateInfrastructureAccountParams) *api.Response
+	AddOrUpdateConfigItem(ctx *gin.Context, params *config_items.AddOrUpdateConfigItemParams) config_items.AddOrUpdateConfigItemResponder
+	CreateCluster(ctx *gin.Context, params *clusters.CreateClusterParams) clusters.CreateClusterResponder
+	CreateInfrastructureAccount(ctx *gin.Context, params *infrastructure_accounts.CreateInfrastructureAccountParams) infrastructure_accounts.CreateInfrastructureAccountResponder
+	CreateOrUpdateNodePool(ctx *gin.Context, params *node_pools.CreateOrUpdateNodePoolParams) node_pools.CreateOrUpdateNodePoolResponder
+	DeleteCluster(ctx *gin.Context, params *clusters.DeleteClusterParams) clusters.DeleteClusterResponder
+	DeleteConfigItem(ctx *gin.Context, params *config_items.DeleteConfigItemParams) config_items.DeleteConfigItemResponder
+	DeleteNodePool(ctx *gin.Context, params *node_pools.DeleteNodePoolParams) node_pools.DeleteNodePoolResponder
+	GetCluster(ctx *gin.Context, params *clusters.GetClusterParams) clusters.GetClusterResponder
+	GetInfrastructureAccount(ctx *gin.Context, params *infrastructure_accounts.GetInfrastructureAccountParams) infrastructure_accounts.GetInfrastructureAccountResponder
+	ListClusters(ctx *gin.Context, params *clusters.ListClustersParams) clusters.ListClustersResponder
+	ListInfrastructureAccounts(ctx *gin.Context) infrastructure_accounts.ListInfrastructureAccountsResponder
+	ListNodePools(ctx *gin.Context, params *node_pools.ListNodePoolsParams) node_pools.ListNodePoolsResponder
+	UpdateCluster(ctx *gin.Context, params *clusters.UpdateClusterParams) clusters.UpdateClusterResponder
+	// UpdateClusterETag returns the current entity tag of the resource
+	// modified by UpdateCluster, or an empty string if it doesn't exist.
+	UpdateClusterETag(ctx *gin.Context, params *clusters.UpdateClusterParams) (string, error)
+	UpdateInfrastructureAccount(ctx *gin.Context, params *infrastructure_accounts.UpdateInfrastructureAccountParams) infrastructure_accounts.UpdateInfrastructureAccountResponder
 }
 
 func ginizePath(path string) string {
 	return strings.Replace(strings.Replace(path, "{", ":", -1), "}", "", -1)
 }
 
+// mustParseDuration parses a duration of a vendor extension of the spec.
+// The durations are validated when generating the code.
+func mustParseDuration(s string) time.Duration {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// newOAuth2Authenticator creates the Authenticator of the OAuth2
+// security definition requiring the scopes.
+func newOAuth2Authenticator(config *Config, jwtVerifier *middleware.JWTVerifier, scopes ...string) middleware.Authenticator {
+	if jwtVerifier != nil {
+		return middleware.JWTAuthenticator(jwtVerifier, scopes...)
+	}
+
+	tokenURL := config.TokenURL
+	if tokenURL == "" {
+		tokenURL = "https://info.services.auth.zalando.com/oauth2/tokeninfo"
+	}
+	return middleware.TokenInfoAuthenticator(tokenURL, scopes...)
+}
+
 // initializeRoutes initializes the route structure for the Server service.
-func initializeRoutes(enableAuth bool, tokenURL string, tracer opentracing.Tracer) *Routes {
+func initializeRoutes(config *Config) *Routes {
+	enableAuth := !config.AuthDisabled
+	tracer := config.Tracer
+
+	var jwtVerifier *middleware.JWTVerifier
+	if config.JWT.JWKSURL != "" {
+		jwtVerifier = middleware.NewJWTVerifier(config.JWT)
+	}
+
+	var responseValidator *middleware.ResponseValidator
+	if config.ValidateResponses {
+		var err error
+		responseValidator, err = middleware.NewResponseValidator(SwaggerJSON)
+		if err != nil {
+			// the embedded spec is validated at generation time.
+			panic(err)
+		}
+	}
+
 	engine := gin.New()
 	engine.Use(gin.Recovery())
 	routes := &Routes{Engine: engine}
 
+	var requestMetrics *metrics.Metrics
+	if config.Metrics {
+		registry := config.MetricsRegistry
+		if registry == nil {
+			registry = metrics.NewRegistry()
+		}
+
+		var err error
+		requestMetrics, err = metrics.New(registry)
+		if err != nil {
+			// only fails if the registry is shared with another server.
+			panic(err)
+		}
+
+		metricsPath := config.MetricsPath
+		if metricsPath == "" {
+			metricsPath = defaultMetricsPath
+		}
+		routes.GET(metricsPath, metrics.Handler(registry))
+	}
+
 	routes.AddOrUpdateConfigItem.RouterGroup = routes.Group("/")
+	if requestMetrics != nil {
+		routes.AddOrUpdateConfigItem.RouterGroup.Use(requestMetrics.Middleware("add_or_update_config_item"))
+	}
 	routes.AddOrUpdateConfigItem.RouterGroup.Use(middleware.LogrusLogger())
+	routes.AddOrUpdateConfigItem.RouterGroup.Use(middleware.CORS(config.CORS))
+	routes.AddOrUpdateConfigItem.RouterGroup.Use(middleware.Timeout(config.RequestTimeout))
+	routes.AddOrUpdateConfigItem.RouterGroup.Use(middleware.MaxBodyBytes(config.MaxBodyBytes))
 	if tracer != nil {
 		routes.AddOrUpdateConfigItem.RouterGroup.Use(tracing.InitSpan(tracer, "add_or_update_config_item"))
+	} else if config.TracerProvider != nil {
+		routes.AddOrUpdateConfigItem.RouterGroup.Use(oteltracing.InitSpan(config.TracerProvider, config.Propagator, "addOrUpdateConfigItem"))
+	}
+	if responseValidator != nil {
+		routes.AddOrUpdateConfigItem.RouterGroup.Use(middleware.ValidateResponses(responseValidator, "addOrUpdateConfigItem", config.ValidateResponsesStrict))
 	}
 	routes.AddOrUpdateConfigItem.RouterGroup.Use(middleware.ContentTypes("application/json"))
+	routes.AddOrUpdateConfigItem.RouterGroup.Use(middleware.Produces("application/json"))
 	if enableAuth {
-
-		routeTokenURL := tokenURL
-		if routeTokenURL == "" {
-			routeTokenURL = "https://info.services.auth.zalando.com/oauth2/tokeninfo"
-		}
-		routes.AddOrUpdateConfigItem.Auth = ginoauth2.Auth(
-			middleware.ScopesAuth("uid"),
-			oauth2.Endpoint{
-				TokenURL: routeTokenURL,
+		routes.AddOrUpdateConfigItem.Auth = middleware.SecurityRequirements(
+			middleware.SecurityRequirement{
+				{Name: "OAuth2", Authenticate: newOAuth2Authenticator(config, jwtVerifier, "uid")},
 			},
 		)
-
 	}
 
 	routes.CreateCluster.RouterGroup = routes.Group("/")
+	if requestMetrics != nil {
+		routes.CreateCluster.RouterGroup.Use(requestMetrics.Middleware("create_cluster"))
+	}
 	routes.CreateCluster.RouterGroup.Use(middleware.LogrusLogger())
+	routes.CreateCluster.RouterGroup.Use(middleware.CORS(config.CORS, "Location"))
+	routes.CreateCluster.RouterGroup.Use(middleware.Timeout(config.RequestTimeout))
+	routes.CreateCluster.RouterGroup.Use(middleware.MaxBodyBytes(config.MaxBodyBytes))
 	if tracer != nil {
 		routes.CreateCluster.RouterGroup.Use(tracing.InitSpan(tracer, "create_cluster"))
+	} else if config.TracerProvider != nil {
+		routes.CreateCluster.RouterGroup.Use(oteltracing.InitSpan(config.TracerProvider, config.Propagator, "createCluster"))
+	}
+	if responseValidator != nil {
+		routes.CreateCluster.RouterGroup.Use(middleware.ValidateResponses(responseValidator, "createCluster", config.ValidateResponsesStrict))
 	}
 	routes.CreateCluster.RouterGroup.Use(middleware.ContentTypes("application/json"))
+	routes.CreateCluster.RouterGroup.Use(middleware.Produces("application/json"))
 	if enableAuth {
-
-		routeTokenURL := tokenURL
-		if routeTokenURL == "" {
-			routeTokenURL = "https://info.services.auth.zalando.com/oauth2/tokeninfo"
-		}
-		routes.CreateCluster.Auth = ginoauth2.Auth(
-			middleware.ScopesAuth("uid"),
-			oauth2.Endpoint{
-				TokenURL: routeTokenURL,
+		routes.CreateCluster.Auth = middleware.SecurityRequirements(
+			middleware.SecurityRequirement{
+				{Name: "OAuth2", Authenticate: newOAuth2Authenticator(config, jwtVerifier, "uid")},
 			},
 		)
-
 	}
 
 	routes.CreateInfrastructureAccount.RouterGroup = routes.Group("/")
+	if requestMetrics != nil {
+		routes.CreateInfrastructureAccount.RouterGroup.Use(requestMetrics.Middleware("create_infrastructure_account"))
+	}
 	routes.CreateInfrastructureAccount.RouterGroup.Use(middleware.LogrusLogger())
+	routes.CreateInfrastructureAccount.RouterGroup.Use(middleware.CORS(config.CORS))
+	routes.CreateInfrastructureAccount.RouterGroup.Use(middleware.Timeout(config.RequestTimeout))
+	routes.CreateInfrastructureAccount.RouterGroup.Use(middleware.MaxBodyBytes(config.MaxBodyBytes))
 	if tracer != nil {
 		routes.CreateInfrastructureAccount.RouterGroup.Use(tracing.InitSpan(tracer, "create_infrastructure_account"))
+	} else if config.TracerProvider != nil {
+		routes.CreateInfrastructureAccount.RouterGroup.Use(oteltracing.InitSpan(config.TracerProvider, config.Propagator, "createInfrastructureAccount"))
+	}
+	if responseValidator != nil {
+		routes.CreateInfrastructureAccount.RouterGroup.Use(middleware.ValidateResponses(responseValidator, "createInfrastructureAccount", config.ValidateResponsesStrict))
 	}
 	routes.CreateInfrastructureAccount.RouterGroup.Use(middleware.ContentTypes("application/json"))
+	routes.CreateInfrastructureAccount.RouterGroup.Use(middleware.Produces("application/json"))
 	if enableAuth {
-
-		routeTokenURL := tokenURL
-		if routeTokenURL == "" {
-			routeTokenURL = "https://info.services.auth.zalando.com/oauth2/tokeninfo"
-		}
-		routes.CreateInfrastructureAccount.Auth = ginoauth2.Auth(
-			middleware.ScopesAuth("uid", "write"),
-			oauth2.Endpoint{
-				TokenURL: routeTokenURL,
+		routes.CreateInfrastructureAccount.Auth = middleware.SecurityRequirements(
+			middleware.SecurityRequirement{
+				{Name: "OAuth2", Authenticate: newOAuth2Authenticator(config, jwtVerifier, "uid", "write")},
 			},
 		)
-
 	}
 
 	routes.CreateOrUpdateNodePool.RouterGroup = routes.Group("/")
+	if requestMetrics != nil {
+		routes.CreateOrUpdateNodePool.RouterGroup.Use(requestMetrics.Middleware("create_or_update_node_pool"))
+	}
 	routes.CreateOrUpdateNodePool.RouterGroup.Use(middleware.LogrusLogger())
+	routes.CreateOrUpdateNodePool.RouterGroup.Use(middleware.CORS(config.CORS))
+	routes.CreateOrUpdateNodePool.RouterGroup.Use(middleware.Timeout(config.RequestTimeout))
+	routes.CreateOrUpdateNodePool.RouterGroup.Use(middleware.MaxBodyBytes(config.MaxBodyBytes))
 	if tracer != nil {
 		routes.CreateOrUpdateNodePool.RouterGroup.Use(tracing.InitSpan(tracer, "create_or_update_node_pool"))
+	} else if config.TracerProvider != nil {
+		routes.CreateOrUpdateNodePool.RouterGroup.Use(oteltracing.InitSpan(config.TracerProvider, config.Propagator, "createOrUpdateNodePool"))
+	}
+	if responseValidator != nil {
+		routes.CreateOrUpdateNodePool.RouterGroup.Use(middleware.ValidateResponses(responseValidator, "createOrUpdateNodePool", config.ValidateResponsesStrict))
 	}
 	routes.CreateOrUpdateNodePool.RouterGroup.Use(middleware.ContentTypes("application/json"))
+	routes.CreateOrUpdateNodePool.RouterGroup.Use(middleware.Produces("application/json"))
 	if enableAuth {
-
-		routeTokenURL := tokenURL
-		if routeTokenURL == "" {
-			routeTokenURL = "https://info.services.auth.zalando.com/oauth2/tokeninfo"
-		}
-		routes.CreateOrUpdateNodePool.Auth = ginoauth2.Auth(
-			middleware.ScopesAuth("uid"),
-			oauth2.Endpoint{
-				TokenURL: routeTokenURL,
+		routes.CreateOrUpdateNodePool.Auth = middleware.SecurityRequirements(
+			middleware.SecurityRequirement{
+				{Name: "OAuth2", Authenticate: newOAuth2Authenticator(config, jwtVerifier, "uid")},
 			},
 		)
-
 	}
 
 	routes.DeleteCluster.RouterGroup = routes.Group("/")
+	if requestMetrics != nil {
+		routes.DeleteCluster.RouterGroup.Use(requestMetrics.Middleware("delete_cluster"))
+	}
 	routes.DeleteCluster.RouterGroup.Use(middleware.LogrusLogger())
+	routes.DeleteCluster.RouterGroup.Use(middleware.CORS(config.CORS))
+	routes.DeleteCluster.RouterGroup.Use(middleware.Timeout(config.RequestTimeout))
 	if tracer != nil {
 		routes.DeleteCluster.RouterGroup.Use(tracing.InitSpan(tracer, "delete_cluster"))
+	} else if config.TracerProvider != nil {
+		routes.DeleteCluster.RouterGroup.Use(oteltracing.InitSpan(config.TracerProvider, config.Propagator, "deleteCluster"))
+	}
+	if responseValidator != nil {
+		routes.DeleteCluster.RouterGroup.Use(middleware.ValidateResponses(responseValidator, "deleteCluster", config.ValidateResponsesStrict))
 	}
+	routes.DeleteCluster.RouterGroup.Use(middleware.Produces("application/json"))
 	if enableAuth {
-
-		routeTokenURL := tokenURL
-		if routeTokenURL == "" {
-			routeTokenURL = "https://info.services.auth.zalando.com/oauth2/tokeninfo"
-		}
-		routes.DeleteCluster.Auth = ginoauth2.Auth(
-			middleware.ScopesAuth("uid"),
-			oauth2.Endpoint{
-				TokenURL: routeTokenURL,
+		routes.DeleteCluster.Auth = middleware.SecurityRequirements(
+			middleware.SecurityRequirement{
+				{Name: "OAuth2", Authenticate: newOAuth2Authenticator(config, jwtVerifier, "uid")},
 			},
 		)
-
 	}
 
 	routes.DeleteConfigItem.RouterGroup = routes.Group("/")
+	if requestMetrics != nil {
+		routes.DeleteConfigItem.RouterGroup.Use(requestMetrics.Middleware("delete_config_item"))
+	}
 	routes.DeleteConfigItem.RouterGroup.Use(middleware.LogrusLogger())
+	routes.DeleteConfigItem.RouterGroup.Use(middleware.CORS(config.CORS))
+	routes.DeleteConfigItem.RouterGroup.Use(middleware.Timeout(config.RequestTimeout))
 	if tracer != nil {
 		routes.DeleteConfigItem.RouterGroup.Use(tracing.InitSpan(tracer, "delete_config_item"))
+	} else if config.TracerProvider != nil {
+		routes.DeleteConfigItem.RouterGroup.Use(oteltracing.InitSpan(config.TracerProvider, config.Propagator, "deleteConfigItem"))
+	}
+	if responseValidator != nil {
+		routes.DeleteConfigItem.RouterGroup.Use(middleware.ValidateResponses(responseValidator, "deleteConfigItem", config.ValidateResponsesStrict))
 	}
+	routes.DeleteConfigItem.RouterGroup.Use(middleware.Produces("application/json"))
 	if enableAuth {
-
-		routeTokenURL := tokenURL
-		if routeTokenURL == "" {
-			routeTokenURL = "https://info.services.auth.zalando.com/oauth2/tokeninfo"
-		}
-		routes.DeleteConfigItem.Auth = ginoauth2.Auth(
-			middleware.ScopesAuth("uid"),
-			oauth2.Endpoint{
-				TokenURL: routeTokenURL,
+		routes.DeleteConfigItem.Auth = middleware.SecurityRequirements(
+			middleware.SecurityRequirement{
+				{Name: "OAuth2", Authenticate: newOAuth2Authenticator(config, jwtVerifier, "uid")},
 			},
 		)
-
 	}
 
 	routes.DeleteNodePool.RouterGroup = routes.Group("/")
+	if requestMetrics != nil {
+		routes.DeleteNodePool.RouterGroup.Use(requestMetrics.Middleware("delete_node_pool"))
+	}
 	routes.DeleteNodePool.RouterGroup.Use(middleware.LogrusLogger())
+	routes.DeleteNodePool.RouterGroup.Use(middleware.CORS(config.CORS))
+	routes.DeleteNodePool.RouterGroup.Use(middleware.Timeout(config.RequestTimeout))
 	if tracer != nil {
 		routes.DeleteNodePool.RouterGroup.Use(tracing.InitSpan(tracer, "delete_node_pool"))
+	} else if config.TracerProvider != nil {
+		routes.DeleteNodePool.RouterGroup.Use(oteltracing.InitSpan(config.TracerProvider, config.Propagator, "deleteNodePool"))
 	}
+	if responseValidator != nil {
+		routes.DeleteNodePool.RouterGroup.Use(middleware.ValidateResponses(responseValidator, "deleteNodePool", config.ValidateResponsesStrict))
+	}
+	routes.DeleteNodePool.RouterGroup.Use(middleware.Produces("application/json"))
 	if enableAuth {
-
-		routeTokenURL := tokenURL
-		if routeTokenURL == "" {
-			routeTokenURL = "https://info.services.auth.zalando.com/oauth2/tokeninfo"
-		}
-		routes.DeleteNodePool.Auth = ginoauth2.Auth(
-			middleware.ScopesAuth("uid"),
-			oauth2.Endpoint{
-				TokenURL: routeTokenURL,
+		routes.DeleteNodePool.Auth = middleware.SecurityRequirements(
+			middleware.SecurityRequirement{
+				{Name: "OAuth2", Authenticate: newOAuth2Authenticator(config, jwtVerifier, "uid")},
 			},
 		)
-
 	}
 
 	routes.GetCluster.RouterGroup = routes.Group("/")
+	if requestMetrics != nil {
+		routes.GetCluster.RouterGroup.Use(requestMetrics.Middleware("get_cluster"))
+	}
 	routes.GetCluster.RouterGroup.Use(middleware.LogrusLogger())
+	routes.GetCluster.RouterGroup.Use(middleware.CORS(config.CORS))
+	routes.GetCluster.RouterGroup.Use(middleware.Timeout(config.RequestTimeout))
+	if config.ETags {
+		routes.GetCluster.RouterGroup.Use(middleware.ComputeETags())
+	}
 	if tracer != nil {
 		routes.GetCluster.RouterGroup.Use(tracing.InitSpan(tracer, "get_cluster"))
+	} else if config.TracerProvider != nil {
+		routes.GetCluster.RouterGroup.Use(oteltracing.InitSpan(config.TracerProvider, config.Propagator, "getCluster"))
+	}
+	if responseValidator != nil {
+		routes.GetCluster.RouterGroup.Use(middleware.ValidateResponses(responseValidator, "getCluster", config.ValidateResponsesStrict))
 	}
+	routes.GetCluster.RouterGroup.Use(middleware.Produces("application/json"))
 	if enableAuth {
-
-		routeTokenURL := tokenURL
-		if routeTokenURL == "" {
-			routeTokenURL = "https://info.services.auth.zalando.com/oauth2/tokeninfo"
-		}
-		routes.GetCluster.Auth = ginoauth2.Auth(
-			middleware.ScopesAuth("uid"),
-			oauth2.Endpoint{
-				TokenURL: routeTokenURL,
+		routes.GetCluster.Auth = middleware.SecurityRequirements(
+			middleware.SecurityRequirement{
+				{Name: "OAuth2", Authenticate: newOAuth2Authenticator(config, jwtVerifier, "uid")},
 			},
 		)
-
 	}
 
 	routes.GetInfrastructureAccount.RouterGroup = routes.Group("/")
+	if requestMetrics != nil {
+		routes.GetInfrastructureAccount.RouterGroup.Use(requestMetrics.Middleware("get_infrastructure_account"))
+	}
 	routes.GetInfrastructureAccount.RouterGroup.Use(middleware.LogrusLogger())
+	routes.GetInfrastructureAccount.RouterGroup.Use(middleware.CORS(config.CORS))
+	routes.GetInfrastructureAccount.RouterGroup.Use(middleware.Timeout(config.RequestTimeout))
+	if config.ETags {
+		routes.GetInfrastructureAccount.RouterGroup.Use(middleware.ComputeETags())
+	}
 	if tracer != nil {
 		routes.GetInfrastructureAccount.RouterGroup.Use(tracing.InitSpan(tracer, "get_infrastructure_account"))
+	} else if config.TracerProvider != nil {
+		routes.GetInfrastructureAccount.RouterGroup.Use(oteltracing.InitSpan(config.TracerProvider, config.Propagator, "getInfrastructureAccount"))
 	}
+	if responseValidator != nil {
+		routes.GetInfrastructureAccount.RouterGroup.Use(middleware.ValidateResponses(responseValidator, "getInfrastructureAccount", config.ValidateResponsesStrict))
+	}
+	routes.GetInfrastructureAccount.RouterGroup.Use(middleware.Produces("application/json"))
 	if enableAuth {
-
-		routeTokenURL := tokenURL
-		if routeTokenURL == "" {
-			routeTokenURL = "https://info.services.auth.zalando.com/oauth2/tokeninfo"
-		}
-		routes.GetInfrastructureAccount.Auth = ginoauth2.Auth(
-			middleware.ScopesAuth("uid"),
-			oauth2.Endpoint{
-				TokenURL: routeTokenURL,
+		routes.GetInfrastructureAccount.Auth = middleware.SecurityRequirements(
+			middleware.SecurityRequirement{
+				{Name: "OAuth2", Authenticate: newOAuth2Authenticator(config, jwtVerifier, "uid")},
 			},
 		)
-
 	}
 
 	routes.ListClusters.RouterGroup = routes.Group("/")
+	if requestMetrics != nil {
+		routes.ListClusters.RouterGroup.Use(requestMetrics.Middleware("list_clusters"))
+	}
 	routes.ListClusters.RouterGroup.Use(middleware.LogrusLogger())
+	routes.ListClusters.RouterGroup.Use(middleware.CORS(config.CORS, "X-Total-Count"))
+	routes.ListClusters.RouterGroup.Use(middleware.Timeout(mustParseDuration("30s")))
+	if config.ETags {
+		routes.ListClusters.RouterGroup.Use(middleware.ComputeETags())
+	}
 	if tracer != nil {
 		routes.ListClusters.RouterGroup.Use(tracing.InitSpan(tracer, "list_clusters"))
+	} else if config.TracerProvider != nil {
+		routes.ListClusters.RouterGroup.Use(oteltracing.InitSpan(config.TracerProvider, config.Propagator, "listClusters"))
 	}
+	if responseValidator != nil {
+		routes.ListClusters.RouterGroup.Use(middleware.ValidateResponses(responseValidator, "listClusters", config.ValidateResponsesStrict))
+	}
+	routes.ListClusters.RouterGroup.Use(middleware.Produces("application/json"))
 	if enableAuth {
-
-		routeTokenURL := tokenURL
-		if routeTokenURL == "" {
-			routeTokenURL = "https://info.services.auth.zalando.com/oauth2/tokeninfo"
-		}
-		routes.ListClusters.Auth = ginoauth2.Auth(
-			middleware.ScopesAuth("uid"),
-			oauth2.Endpoint{
-				TokenURL: routeTokenURL,
+		routes.ListClusters.Auth = middleware.SecurityRequirements(
+			middleware.SecurityRequirement{
+				{Name: "OAuth2", Authenticate: newOAuth2Authenticator(config, jwtVerifier, "uid")},
 			},
 		)
-
 	}
 
 	routes.ListInfrastructureAccounts.RouterGroup = routes.Group("/")
+	if requestMetrics != nil {
+		routes.ListInfrastructureAccounts.RouterGroup.Use(requestMetrics.Middleware("list_infrastructure_accounts"))
+	}
 	routes.ListInfrastructureAccounts.RouterGroup.Use(middleware.LogrusLogger())
+	routes.ListInfrastructureAccounts.RouterGroup.Use(middleware.CORS(config.CORS))
+	routes.ListInfrastructureAccounts.RouterGroup.Use(middleware.Timeout(config.RequestTimeout))
+	if config.ETags {
+		routes.ListInfrastructureAccounts.RouterGroup.Use(middleware.ComputeETags())
+	}
 	if tracer != nil {
 		routes.ListInfrastructureAccounts.RouterGroup.Use(tracing.InitSpan(tracer, "list_infrastructure_accounts"))
+	} else if config.TracerProvider != nil {
+		routes.ListInfrastructureAccounts.RouterGroup.Use(oteltracing.InitSpan(config.TracerProvider, config.Propagator, "listInfrastructureAccounts"))
+	}
+	if responseValidator != nil {
+		routes.ListInfrastructureAccounts.RouterGroup.Use(middleware.ValidateResponses(responseValidator, "listInfrastructureAccounts", config.ValidateResponsesStrict))
 	}
+	routes.ListInfrastructureAccounts.RouterGroup.Use(middleware.Produces("application/json"))
 	if enableAuth {
-
-		routeTokenURL := tokenURL
-		if routeTokenURL == "" {
-			routeTokenURL = "https://info.services.auth.zalando.com/oauth2/tokeninfo"
-		}
-		routes.ListInfrastructureAccounts.Auth = ginoauth2.Auth(
-			middleware.ScopesAuth("uid"),
-			oauth2.Endpoint{
-				TokenURL: routeTokenURL,
+		routes.ListInfrastructureAccounts.Auth = middleware.SecurityRequirements(
+			middleware.SecurityRequirement{
+				{Name: "OAuth2", Authenticate: newOAuth2Authenticator(config, jwtVerifier, "uid")},
 			},
 		)
-
 	}
 
 	routes.ListNodePools.RouterGroup = routes.Group("/")
+	if requestMetrics != nil {
+		routes.ListNodePools.RouterGroup.Use(requestMetrics.Middleware("list_node_pools"))
+	}
 	routes.ListNodePools.RouterGroup.Use(middleware.LogrusLogger())
+	routes.ListNodePools.RouterGroup.Use(middleware.CORS(config.CORS))
+	routes.ListNodePools.RouterGroup.Use(middleware.Timeout(config.RequestTimeout))
+	if config.ETags {
+		routes.ListNodePools.RouterGroup.Use(middleware.ComputeETags())
+	}
 	if tracer != nil {
 		routes.ListNodePools.RouterGroup.Use(tracing.InitSpan(tracer, "list_node_pools"))
+	} else if config.TracerProvider != nil {
+		routes.ListNodePools.RouterGroup.Use(oteltracing.InitSpan(config.TracerProvider, config.Propagator, "listNodePools"))
+	}
+	if responseValidator != nil {
+		routes.ListNodePools.RouterGroup.Use(middleware.ValidateResponses(responseValidator, "listNodePools", config.ValidateResponsesStrict))
 	}
+	routes.ListNodePools.RouterGroup.Use(middleware.Produces("application/json"))
 	if enableAuth {
-
-		routeTokenURL := tokenURL
-		if routeTokenURL == "" {
-			routeTokenURL = "https://info.services.auth.zalando.com/oauth2/tokeninfo"
-		}
-		routes.ListNodePools.Auth = ginoauth2.Auth(
-			middleware.ScopesAuth("uid"),
-			oauth2.Endpoint{
-				TokenURL: routeTokenURL,
+		routes.ListNodePools.Auth = middleware.SecurityRequirements(
+			middleware.SecurityRequirement{
+				{Name: "OAuth2", Authenticate: newOAuth2Authenticator(config, jwtVerifier, "uid")},
 			},
 		)
-
 	}
 
 	routes.UpdateCluster.RouterGroup = routes.Group("/")
+	if requestMetrics != nil {
+		routes.UpdateCluster.RouterGroup.Use(requestMetrics.Middleware("update_cluster"))
+	}
 	routes.UpdateCluster.RouterGroup.Use(middleware.LogrusLogger())
+	routes.UpdateCluster.RouterGroup.Use(middleware.CORS(config.CORS))
+	routes.UpdateCluster.RouterGroup.Use(middleware.Timeout(config.RequestTimeout))
+	routes.UpdateCluster.RouterGroup.Use(middleware.MaxBodyBytes(config.MaxBodyBytes))
 	if tracer != nil {
 		routes.UpdateCluster.RouterGroup.Use(tracing.InitSpan(tracer, "update_cluster"))
+	} else if config.TracerProvider != nil {
+		routes.UpdateCluster.RouterGroup.Use(oteltracing.InitSpan(config.TracerProvider, config.Propagator, "updateCluster"))
+	}
+	if responseValidator != nil {
+		routes.UpdateCluster.RouterGroup.Use(middleware.ValidateResponses(responseValidator, "updateCluster", config.ValidateResponsesStrict))
 	}
 	routes.UpdateCluster.RouterGroup.Use(middleware.ContentTypes("application/json"))
+	routes.UpdateCluster.RouterGroup.Use(middleware.Produces("application/json"))
 	if enableAuth {
-
-		routeTokenURL := tokenURL
-		if routeTokenURL == "" {
-			routeTokenURL = "https://info.services.auth.zalando.com/oauth2/tokeninfo"
-		}
-		routes.UpdateCluster.Auth = ginoauth2.Auth(
-			middleware.ScopesAuth("uid"),
-			oauth2.Endpoint{
-				TokenURL: routeTokenURL,
+		routes.UpdateCluster.Auth = middleware.SecurityRequirements(
+			middleware.SecurityRequirement{
+				{Name: "OAuth2", Authenticate: newOAuth2Authenticator(config, jwtVerifier, "uid")},
 			},
 		)
-
 	}
 
 	routes.UpdateInfrastructureAccount.RouterGroup = routes.Group("/")
+	if requestMetrics != nil {
+		routes.UpdateInfrastructureAccount.RouterGroup.Use(requestMetrics.Middleware("update_infrastructure_account"))
+	}
 	routes.UpdateInfrastructureAccount.RouterGroup.Use(middleware.LogrusLogger())
+	routes.UpdateInfrastructureAccount.RouterGroup.Use(middleware.CORS(config.CORS))
+	routes.UpdateInfrastructureAccount.RouterGroup.Use(middleware.Timeout(config.RequestTimeout))
+	routes.UpdateInfrastructureAccount.RouterGroup.Use(middleware.MaxBodyBytes(config.MaxBodyBytes))
 	if tracer != nil {
 		routes.UpdateInfrastructureAccount.RouterGroup.Use(tracing.InitSpan(tracer, "update_infrastructure_account"))
+	} else if config.TracerProvider != nil {
+		routes.UpdateInfrastructureAccount.RouterGroup.Use(oteltracing.InitSpan(config.TracerProvider, config.Propagator, "updateInfrastructureAccount"))
+	}
+	if responseValidator != nil {
+		routes.UpdateInfrastructureAccount.RouterGroup.Use(middleware.ValidateResponses(responseValidator, "updateInfrastructureAccount", config.ValidateResponsesStrict))
 	}
 	routes.UpdateInfrastructureAccount.RouterGroup.Use(middleware.ContentTypes("application/json"))
+	routes.UpdateInfrastructureAccount.RouterGroup.Use(middleware.Produces("application/json"))
 	if enableAuth {
-
-		routeTokenURL := tokenURL
-		if routeTokenURL == "" {
-			routeTokenURL = "https://info.services.auth.zalando.com/oauth2/tokeninfo"
-		}
-		routes.UpdateInfrastructureAccount.Auth = ginoauth2.Auth(
-			middleware.ScopesAuth("uid", "write"),
-			oauth2.Endpoint{
-				TokenURL: routeTokenURL,
+		routes.UpdateInfrastructureAccount.Auth = middleware.SecurityRequirements(
+			middleware.SecurityRequirement{
+				{Name: "OAuth2", Authenticate: newOAuth2Authenticator(config, jwtVerifier, "uid", "write")},
 			},
 		)
+	}
 
+	// answer the CORS preflight requests of every path with the methods of
+	// its operations and the headers they accept.
+	if config.CORS.Enabled() {
+		// the Idempotency-Key header is accepted by the POST and PATCH
+		// operations if idempotency is enabled for all of them.
+		var idempotencyHeaders []string
+		if config.Idempotency {
+			idempotencyHeaders = []string{middleware.IdempotencyKeyHeader}
+		}
+		routes.Group("/").OPTIONS(ginizePath("/infrastructure-accounts"), middleware.CORSPreflight(config.CORS,
+			[]string{"GET", "POST"},
+			append([]string{"Authorization", "Content-Type", "If-Modified-Since", "If-None-Match"}, idempotencyHeaders...),
+		))
+		routes.Group("/").OPTIONS(ginizePath("/infrastructure-accounts/{account_id}"), middleware.CORSPreflight(config.CORS,
+			[]string{"GET", "PATCH"},
+			append([]string{"Authorization", "Content-Type", "If-Modified-Since", "If-None-Match"}, idempotencyHeaders...),
+		))
+		routes.Group("/").OPTIONS(ginizePath("/kubernetes-clusters"), middleware.CORSPreflight(config.CORS,
+			[]string{"GET", "POST"},
+			[]string{"Authorization", "Content-Type", "Idempotency-Key", "If-Modified-Since", "If-None-Match"},
+		))
+		routes.Group("/").OPTIONS(ginizePath("/kubernetes-clusters/{cluster_id}"), middleware.CORSPreflight(config.CORS,
+			[]string{"DELETE", "GET", "PATCH"},
+			append([]string{"Authorization", "Content-Type", "If-Match", "If-Modified-Since", "If-None-Match"}, idempotencyHeaders...),
+		))
+		routes.Group("/").OPTIONS(ginizePath("/kubernetes-clusters/{cluster_id}/config-items/{config_key}"), middleware.CORSPreflight(config.CORS,
+			[]string{"DELETE", "PUT"},
+			[]string{"Authorization", "Content-Type"},
+		))
+		routes.Group("/").OPTIONS(ginizePath("/kubernetes-clusters/{cluster_id}/node-pools"), middleware.CORSPreflight(config.CORS,
+			[]string{"GET"},
+			[]string{"Authorization", "If-Modified-Since", "If-None-Match"},
+		))
+		routes.Group("/").OPTIONS(ginizePath("/kubernetes-clusters/{cluster_id}/node-pools/{node_pool_name}"), middleware.CORSPreflight(config.CORS,
+			[]string{"DELETE", "PUT"},
+			[]string{"Authorization", "Content-Type"},
+		))
 	}
 
 	return routes
@@ -459,8 +641,14 @@ type Server struct {
 	config           *Config
 	server           *http.Server
 	service          Service
-	healthy          bool
+	healthy          atomic.Bool
 	serviceHealthyFn func() bool
+	shutdownHooks    []func(ctx context.Context) error
+	shutdownOnce     sync.Once
+	shutdownDone     chan struct{}
+	shutdownErr      error
+	rateLimitStore   middleware.RateLimitStore
+	idempotencyStore middleware.IdempotencyStore
 	authDisabled     bool
 	Title            string
 	Version          string
@@ -473,16 +661,13 @@ func NewServer(svc Service, config *Config) *Server {
 	}
 
 	server := &Server{
-		Routes: initializeRoutes(
-			!config.AuthDisabled,
-			config.TokenURL,
-			config.Tracer,
-		),
+		Routes:       initializeRoutes(config),
 		service:      svc,
 		config:       config,
 		Title:        "Cluster Registry",
 		Version:      "0.0.1",
 		authDisabled: config.AuthDisabled,
+		shutdownDone: make(chan struct{}),
 	}
 
 	// enable pprof http endpoints in debug mode
@@ -493,15 +678,47 @@ func NewServer(svc Service, config *Config) *Server {
 	// set logrus logger to TextFormatter with no colors
 	log.SetFormatter(&log.TextFormatter{DisableColors: true})
 
+	readTimeout := config.ReadTimeout
+	if readTimeout == 0 {
+		readTimeout = defaultReadTimeout
+	}
+
+	writeTimeout := config.WriteTimeout
+	if writeTimeout == 0 {
+		writeTimeout = defaultWriteTimeout
+	}
+
+	// the operations may take up to the RequestTimeout, longer
+	// x-gin-timeouts extend the deadlines of their connections.
+	if readTimeout < config.RequestTimeout {
+		readTimeout = config.RequestTimeout
+	}
+	if writeTimeout < config.RequestTimeout {
+		writeTimeout = config.RequestTimeout
+	}
+
 	server.server = &http.Server{
-		Addr:         config.Address,
-		Handler:      server.Routes.Engine,
-		ReadTimeout:  10 * time.Second,
-		WriteTimeout: 10 * time.Second,
+		Addr:              config.Address,
+		Handler:           server.Routes.Engine,
+		ReadTimeout:       readTimeout,
+		ReadHeaderTimeout: config.ReadHeaderTimeout,
+		WriteTimeout:      writeTimeout,
+		IdleTimeout:       config.IdleTimeout,
+		MaxHeaderBytes:    config.MaxHeaderBytes,
 	}
 
 	server.serviceHealthyFn = svc.Healthy
 
+	server.rateLimitStore = config.RateLimitStore
+	if server.rateLimitStore == nil {
+		server.rateLimitStore = middleware.NewMemoryRateLimitStore()
+	}
+
+	server.idempotencyStore = config.IdempotencyStore
+	if server.idempotencyStore == nil {
+		server.idempotencyStore = middleware.NewMemoryIdempotencyStore(defaultIdempotencyTTL)
+	}
+
 	if !config.WellKnownDisabled {
 		server.Routes.configureWellKnown(server.isHealthy)
 	}
@@ -514,7 +731,7 @@ func NewServer(svc Service, config *Config) *Server {
 
 // isHealthy returns true if both the server and the service reports healthy.
 func (s *Server) isHealthy() bool {
-	return s.healthy && s.serviceHealthyFn()
+	return s.healthy.Load() && s.serviceHealthyFn()
 }
 
 // ConfigureRoutes starts the internal configureRoutes methode.
@@ -525,21 +742,97 @@ func (s *Server) ConfigureRoutes() {
 // configureRoutes configures the routes for the Server service.
 // Configuring of routes includes setting up Auth if it is enabled.
 func (s *Server) configureRoutes() {
+	// rate limit by the client IP before the authenticate middleware such
+	// that failed authentication attempts are limited as well.
+	s.Routes.AddOrUpdateConfigItem.Use(middleware.RateLimit(s.rateLimitStore, "add_or_update_config_item", s.config.RateLimit))
+	s.Routes.CreateCluster.Use(middleware.RateLimit(s.rateLimitStore, "create_cluster", s.config.RateLimit))
+	s.Routes.CreateInfrastructureAccount.Use(middleware.RateLimit(s.rateLimitStore, "create_infrastructure_account", s.config.RateLimit))
+	s.Routes.CreateOrUpdateNodePool.Use(middleware.RateLimit(s.rateLimitStore, "create_or_update_node_pool", s.config.RateLimit))
+	s.Routes.DeleteCluster.Use(middleware.RateLimit(s.rateLimitStore, "delete_cluster", s.config.RateLimit))
+	s.Routes.DeleteConfigItem.Use(middleware.RateLimit(s.rateLimitStore, "delete_config_item", s.config.RateLimit))
+	s.Routes.DeleteNodePool.Use(middleware.RateLimit(s.rateLimitStore, "delete_node_pool", s.config.RateLimit))
+	s.Routes.GetCluster.Use(middleware.RateLimit(s.rateLimitStore, "get_cluster", s.config.RateLimit))
+	s.Routes.GetInfrastructureAccount.Use(middleware.RateLimit(s.rateLimitStore, "get_infrastructure_account", s.config.RateLimit))
+	s.Routes.ListClusters.Use(middleware.RateLimit(s.rateLimitStore, "list_clusters", middleware.Rate{Requests: 100, Per: mustParseDuration("1m"), Key: "principal"}))
+	s.Routes.ListInfrastructureAccounts.Use(middleware.RateLimit(s.rateLimitStore, "list_infrastructure_accounts", s.config.RateLimit))
+	s.Routes.ListNodePools.Use(middleware.RateLimit(s.rateLimitStore, "list_node_pools", s.config.RateLimit))
+	s.Routes.UpdateCluster.Use(middleware.RateLimit(s.rateLimitStore, "update_cluster", s.config.RateLimit))
+	s.Routes.UpdateInfrastructureAccount.Use(middleware.RateLimit(s.rateLimitStore, "update_infrastructure_account", s.config.RateLimit))
+
 	if !s.authDisabled {
-		s.Routes.AddOrUpdateConfigItem.Use(s.Routes.AddOrUpdateConfigItem.Auth)
-		s.Routes.CreateCluster.Use(s.Routes.CreateCluster.Auth)
-		s.Routes.CreateInfrastructureAccount.Use(s.Routes.CreateInfrastructureAccount.Auth)
-		s.Routes.CreateOrUpdateNodePool.Use(s.Routes.CreateOrUpdateNodePool.Auth)
-		s.Routes.DeleteCluster.Use(s.Routes.DeleteCluster.Auth)
-		s.Routes.DeleteConfigItem.Use(s.Routes.DeleteConfigItem.Auth)
-		s.Routes.DeleteNodePool.Use(s.Routes.DeleteNodePool.Auth)
-		s.Routes.GetCluster.Use(s.Routes.GetCluster.Auth)
-		s.Routes.GetInfrastructureAccount.Use(s.Routes.GetInfrastructureAccount.Auth)
-		s.Routes.ListClusters.Use(s.Routes.ListClusters.Auth)
-		s.Routes.ListInfrastructureAccounts.Use(s.Routes.ListInfrastructureAccounts.Auth)
-		s.Routes.ListNodePools.Use(s.Routes.ListNodePools.Auth)
-		s.Routes.UpdateCluster.Use(s.Routes.UpdateCluster.Auth)
-		s.Routes.UpdateInfrastructureAccount.Use(s.Routes.UpdateInfrastructureAccount.Auth)
+		if s.Routes.AddOrUpdateConfigItem.Auth != nil {
+			s.Routes.AddOrUpdateConfigItem.Use(s.Routes.AddOrUpdateConfigItem.Auth)
+		}
+		if s.Routes.CreateCluster.Auth != nil {
+			s.Routes.CreateCluster.Use(s.Routes.CreateCluster.Auth)
+		}
+		if s.Routes.CreateInfrastructureAccount.Auth != nil {
+			s.Routes.CreateInfrastructureAccount.Use(s.Routes.CreateInfrastructureAccount.Auth)
+		}
+		if s.Routes.CreateOrUpdateNodePool.Auth != nil {
+			s.Routes.CreateOrUpdateNodePool.Use(s.Routes.CreateOrUpdateNodePool.Auth)
+		}
+		if s.Routes.DeleteCluster.Auth != nil {
+			s.Routes.DeleteCluster.Use(s.Routes.DeleteCluster.Auth)
+		}
+		if s.Routes.DeleteConfigItem.Auth != nil {
+			s.Routes.DeleteConfigItem.Use(s.Routes.DeleteConfigItem.Auth)
+		}
+		if s.Routes.DeleteNodePool.Auth != nil {
+			s.Routes.DeleteNodePool.Use(s.Routes.DeleteNodePool.Auth)
+		}
+		if s.Routes.GetCluster.Auth != nil {
+			s.Routes.GetCluster.Use(s.Routes.GetCluster.Auth)
+		}
+		if s.Routes.GetInfrastructureAccount.Auth != nil {
+			s.Routes.GetInfrastructureAccount.Use(s.Routes.GetInfrastructureAccount.Auth)
+		}
+		if s.Routes.ListClusters.Auth != nil {
+			s.Routes.ListClusters.Use(s.Routes.ListClusters.Auth)
+		}
+		if s.Routes.ListInfrastructureAccounts.Auth != nil {
+			s.Routes.ListInfrastructureAccounts.Use(s.Routes.ListInfrastructureAccounts.Auth)
+		}
+		if s.Routes.ListNodePools.Auth != nil {
+			s.Routes.ListNodePools.Use(s.Routes.ListNodePools.Auth)
+		}
+		if s.Routes.UpdateCluster.Auth != nil {
+			s.Routes.UpdateCluster.Use(s.Routes.UpdateCluster.Auth)
+		}
+		if s.Routes.UpdateInfrastructureAccount.Auth != nil {
+			s.Routes.UpdateInfrastructureAccount.Use(s.Routes.UpdateInfrastructureAccount.Auth)
+		}
+	}
+
+	// rate limit by the principal after the authenticate middleware, if
+	// the rate is keyed by the principal.
+	s.Routes.AddOrUpdateConfigItem.Use(middleware.PrincipalRateLimit(s.rateLimitStore, "add_or_update_config_item", s.config.RateLimit))
+	s.Routes.CreateCluster.Use(middleware.PrincipalRateLimit(s.rateLimitStore, "create_cluster", s.config.RateLimit))
+	s.Routes.CreateInfrastructureAccount.Use(middleware.PrincipalRateLimit(s.rateLimitStore, "create_infrastructure_account", s.config.RateLimit))
+	s.Routes.CreateOrUpdateNodePool.Use(middleware.PrincipalRateLimit(s.rateLimitStore, "create_or_update_node_pool", s.config.RateLimit))
+	s.Routes.DeleteCluster.Use(middleware.PrincipalRateLimit(s.rateLimitStore, "delete_cluster", s.config.RateLimit))
+	s.Routes.DeleteConfigItem.Use(middleware.PrincipalRateLimit(s.rateLimitStore, "delete_config_item", s.config.RateLimit))
+	s.Routes.DeleteNodePool.Use(middleware.PrincipalRateLimit(s.rateLimitStore, "delete_node_pool", s.config.RateLimit))
+	s.Routes.GetCluster.Use(middleware.PrincipalRateLimit(s.rateLimitStore, "get_cluster", s.config.RateLimit))
+	s.Routes.GetInfrastructureAccount.Use(middleware.PrincipalRateLimit(s.rateLimitStore, "get_infrastructure_account", s.config.RateLimit))
+	s.Routes.ListClusters.Use(middleware.PrincipalRateLimit(s.rateLimitStore, "list_clusters", middleware.Rate{Requests: 100, Per: mustParseDuration("1m"), Key: "principal"}))
+	s.Routes.ListInfrastructureAccounts.Use(middleware.PrincipalRateLimit(s.rateLimitStore, "list_infrastructure_accounts", s.config.RateLimit))
+	s.Routes.ListNodePools.Use(middleware.PrincipalRateLimit(s.rateLimitStore, "list_node_pools", s.config.RateLimit))
+	s.Routes.UpdateCluster.Use(middleware.PrincipalRateLimit(s.rateLimitStore, "update_cluster", s.config.RateLimit))
+	s.Routes.UpdateInfrastructureAccount.Use(middleware.PrincipalRateLimit(s.rateLimitStore, "update_infrastructure_account", s.config.RateLimit))
+
+	// replay the responses of repeated requests with an Idempotency-Key
+	// after the authenticate middleware such that the keys are scoped to
+	// the principal.
+	s.Routes.CreateCluster.Use(middleware.Idempotency(s.idempotencyStore, "create_cluster", s.config.MaxBodyBytes))
+	if s.config.Idempotency {
+		s.Routes.CreateInfrastructureAccount.Use(middleware.Idempotency(s.idempotencyStore, "create_infrastructure_account", s.config.MaxBodyBytes))
+	}
+	if s.config.Idempotency {
+		s.Routes.UpdateCluster.Use(middleware.Idempotency(s.idempotencyStore, "update_cluster", s.config.MaxBodyBytes))
+	}
+	if s.config.Idempotency {
+		s.Routes.UpdateInfrastructureAccount.Use(middleware.Idempotency(s.idempotencyStore, "update_infrastructure_account", s.config.MaxBodyBytes))
 	}
 
 	// setup all service routes after the authenticate middleware has been
@@ -556,7 +849,7 @@ func (s *Server) configureRoutes() {
 	s.Routes.ListClusters.GET(ginizePath("/kubernetes-clusters"), clusters.ListClustersEndpoint(s.service.ListClusters))
 	s.Routes.ListInfrastructureAccounts.GET(ginizePath("/infrastructure-accounts"), infrastructure_accounts.ListInfrastructureAccountsEndpoint(s.service.ListInfrastructureAccounts))
 	s.Routes.ListNodePools.GET(ginizePath("/kubernetes-clusters/{cluster_id}/node-pools"), node_pools.ListNodePoolsEndpoint(s.service.ListNodePools))
-	s.Routes.UpdateCluster.PATCH(ginizePath("/kubernetes-clusters/{cluster_id}"), clusters.UpdateClusterEndpoint(s.service.UpdateCluster))
+	s.Routes.UpdateCluster.PATCH(ginizePath("/kubernetes-clusters/{cluster_id}"), clusters.UpdateClusterEndpoint(s.service.UpdateCluster, s.service.UpdateClusterETag))
 	s.Routes.UpdateInfrastructureAccount.PATCH(ginizePath("/infrastructure-accounts/{account_id}"), infrastructure_accounts.UpdateInfrastructureAccountEndpoint(s.service.UpdateInfrastructureAccount))
 }
 
@@ -568,32 +861,83 @@ func (s *Server) Run() error {
 
 	log.Infof("Serving '%s - %s' on address %s", s.Title, s.Version, s.server.Addr)
 	// server is set to healthy when started.
-	s.healthy = true
+	s.healthy.Store(true)
 	if s.config.InsecureHTTP {
 		return s.server.ListenAndServe()
 	}
 	return s.server.ListenAndServeTLS(s.config.TLSCertFile, s.config.TLSKeyFile)
 }
 
-// Shutdown will gracefully shutdown the Server server.
+// OnShutdown registers a hook run by RunWithSigHandler after the server has
+// been shut down. The hooks are run in the order they are registered with a
+// context bounded by the ShutdownTimeout of the config.
+func (s *Server) OnShutdown(hook func(ctx context.Context) error) {
+	s.shutdownHooks = append(s.shutdownHooks, hook)
+}
+
+// Shutdown will gracefully shutdown the Server server. The server reports
+// unhealthy for the ShutdownDrainPeriod of the config while still serving
+// requests, such that load balancers stop routing requests to it, before it
+// stops accepting new connections and waits at most the ShutdownTimeout for
+// the active requests to finish.
+// The server is only shut down once, repeated calls wait for the shutdown
+// to finish and return its error.
 func (s *Server) Shutdown() error {
-	// server is set to unhealthy when shutting down
-	s.healthy = false
-	return s.server.Shutdown(context.Background())
+	s.shutdownOnce.Do(func() {
+		defer close(s.shutdownDone)
+
+		// server is set to unhealthy when shutting down
+		s.healthy.Store(false)
+		time.Sleep(s.config.ShutdownDrainPeriod)
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout())
+		defer cancel()
+		s.shutdownErr = s.server.Shutdown(ctx)
+	})
+	return s.shutdownErr
+}
+
+// shutdownTimeout returns the ShutdownTimeout of the config, or the default
+// if not set.
+func (s *Server) shutdownTimeout() time.Duration {
+	if s.config.ShutdownTimeout > 0 {
+		return s.config.ShutdownTimeout
+	}
+	return defaultShutdownTimeout
+}
+
+// runShutdownHooks runs the hooks with a context bounded by the
+// ShutdownTimeout of the config. All hooks are run and their errors are
+// joined.
+func (s *Server) runShutdownHooks(hooks []func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout())
+	defer cancel()
+
+	var errs []error
+	for _, hook := range hooks {
+		errs = append(errs, hook(ctx))
+	}
+	return errors.Join(errs...)
 }
 
 // RunWithSigHandler runs the Server server with SIGTERM handling automatically
 // enabled. The server will listen for a SIGTERM signal and gracefully shutdown
-// the web server.
-// It's possible to optionally pass any number shutdown functions which will
-// execute one by one after the webserver has been shutdown successfully.
+// the web server. It also returns when the server is shut down by calling
+// Shutdown directly.
+// After the webserver has been shut down the hooks registered with
+// OnShutdown and any number of shutdown functions passed are run one by
+// one. The errors of the shutdown and of all the hooks are returned joined.
 func (s *Server) RunWithSigHandler(shutdown ...func() error) error {
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
 
 	go func() {
-		<-sigCh
-		s.Shutdown()
+		select {
+		case <-sigCh:
+			_ = s.Shutdown()
+		case <-s.shutdownDone:
+		}
 	}()
 
 	err := s.Run()
@@ -603,14 +947,18 @@ func (s *Server) RunWithSigHandler(shutdown ...func() error) error {
 		}
 	}
 
+	// Run returns as soon as the shutdown starts, wait for it to finish.
+	<-s.shutdownDone
+	err = s.shutdownErr
+
+	hooks := s.shutdownHooks
 	for _, fn := range shutdown {
-		err := fn()
-		if err != nil {
-			return err
-		}
+		hooks = append(hooks, func(context.Context) error {
+			return fn()
+		})
 	}
 
-	return nil
+	return errors.Join(err, s.runShutdownHooks(hooks))
 }
 
 // vim: ft=go
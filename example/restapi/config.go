@@ -7,13 +7,26 @@ package restapi
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/alecthomas/kingpin/v2"
+	"github.com/mikkeloscar/gin-swagger/middleware"
 	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
-	defaultAddress = ":8080"
+	defaultAddress      = ":8080"
+	defaultMetricsPath  = "/metrics"
+	defaultReadTimeout  = 10 * time.Second
+	defaultWriteTimeout = 10 * time.Second
+	// defaultShutdownTimeout bounds the shutdown of the server and the
+	// shutdown hooks if no ShutdownTimeout is set.
+	defaultShutdownTimeout = 20 * time.Second
+	defaultRateLimitPeriod = time.Minute
+	defaultIdempotencyTTL  = 24 * time.Hour
 )
 
 // Config defines the config options for the API server.
@@ -26,7 +39,85 @@ type Config struct {
 	TLSKeyFile        string
 	WellKnownDisabled bool
 	TokenURL          string
-	Tracer            opentracing.Tracer
+	// JWT configures local validation of OAuth2 bearer tokens as JWTs. It's
+	// used instead of the TokenURL when JWT.JWKSURL is set.
+	JWT    middleware.JWTConfig
+	Tracer opentracing.Tracer
+	// TracerProvider enables OpenTelemetry tracing of the operations. It's
+	// only used if no opentracing Tracer is set.
+	TracerProvider trace.TracerProvider
+	// Propagator extracts the parent span from the requests when tracing
+	// with the TracerProvider. W3C trace context and baggage are used if
+	// not set.
+	Propagator propagation.TextMapPropagator
+	// ValidateResponses enables validation of the service responses against
+	// the responses defined in the spec. Invalid responses are logged.
+	ValidateResponses bool
+	// ValidateResponsesStrict replaces invalid responses with a 500 Problem
+	// response when ValidateResponses is enabled.
+	ValidateResponsesStrict bool
+	// Metrics enables the Prometheus metrics of the operations. They are
+	// served on the MetricsPath of the API, which should then be kept from
+	// the public, e.g. by the ingress.
+	Metrics bool
+	// MetricsPath is the path the metrics are served on, /metrics by
+	// default.
+	MetricsPath string
+	// MetricsRegistry is the registry the metrics are registered with and
+	// served from. A registry with Go runtime and process metrics is
+	// created if not set.
+	MetricsRegistry *prometheus.Registry
+	// ReadTimeout and WriteTimeout are the timeouts of the HTTP server for
+	// reading the request and writing the response, 10 seconds if not set.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	// ReadHeaderTimeout is the timeout for reading the request headers,
+	// the ReadTimeout if not set.
+	ReadHeaderTimeout time.Duration
+	// IdleTimeout is the timeout for the next request of a keep-alive
+	// connection, the ReadTimeout if not set.
+	IdleTimeout time.Duration
+	// MaxHeaderBytes limits the size of the request headers, 1 MB if not
+	// set.
+	MaxHeaderBytes int
+	// MaxBodyBytes limits the size of the request bodies. Requests with a
+	// larger body are responded with 413. Not limited if not set.
+	MaxBodyBytes int64
+	// RequestTimeout is the deadline of the requests of the operations
+	// without an x-gin-timeout. Shorter read and write timeouts are raised
+	// to it and requests exceeding it are responded with 503. No deadline
+	// is set if not set.
+	RequestTimeout time.Duration
+	// ShutdownDrainPeriod is the time the server reports unhealthy while
+	// still serving requests when shutting down, such that load balancers
+	// stop routing requests to it first.
+	ShutdownDrainPeriod time.Duration
+	// ShutdownTimeout bounds the time waiting for active requests when
+	// shutting down the server, and the time of the shutdown hooks after
+	// it. 20 seconds if not set.
+	ShutdownTimeout time.Duration
+	// RateLimit is the rate limit of the operations without an
+	// x-rate-limit. Not limited if not set.
+	RateLimit middleware.Rate
+	// RateLimitStore stores the token buckets of the rate limits, shared
+	// by all instances of the server if it's backed by a shared store. The
+	// buckets are kept in memory if not set.
+	RateLimitStore middleware.RateLimitStore
+	// CORS configures Cross-Origin Resource Sharing of the operations. The
+	// allowed methods and headers of every path are taken from the spec.
+	// Disabled if no origins are allowed.
+	CORS middleware.CORSConfig
+	// Idempotency enables Idempotency-Key support of the POST and PATCH
+	// operations without an x-idempotent.
+	Idempotency bool
+	// IdempotencyStore stores the responses of the requests with an
+	// Idempotency-Key. The responses are kept in memory for 24 hours if
+	// not set.
+	IdempotencyStore middleware.IdempotencyStore
+	// ETags enables computing strong entity tags from the bodies of the
+	// responses of GET operations which don't set an ETag, such that
+	// requests with a matching If-None-Match are responded with 304.
+	ETags bool
 }
 
 // WithDefaultFlags creates a Config with default address ':8080'
@@ -45,6 +136,60 @@ func (c *Config) WithDefaultFlags() *Config {
 		BoolVar(&c.AuthDisabled)
 	kingpin.Flag("token-url", "Set TokenURL used to validate oauth2 tokens.").
 		StringVar(&c.TokenURL)
+	kingpin.Flag("jwks-url", "URL or file path of the JWKS used to validate oauth2 tokens as JWTs instead of using the token URL.").
+		StringVar(&c.JWT.JWKSURL)
+	kingpin.Flag("jwt-issuer", "Expected issuer of JWTs.").
+		StringVar(&c.JWT.Issuer)
+	kingpin.Flag("jwt-audience", "Expected audience of JWTs.").
+		StringVar(&c.JWT.Audience)
+	kingpin.Flag("jwt-scope-claim", "Claim holding the scopes of JWTs.").
+		Default("scope").StringVar(&c.JWT.ScopeClaim)
+	kingpin.Flag("jwt-uid-claim", "Claim holding the uid of JWTs.").
+		Default("sub").StringVar(&c.JWT.UIDClaim)
+	kingpin.Flag("jwt-realm-claim", "Claim holding the realm of JWTs.").
+		StringVar(&c.JWT.RealmClaim)
+	kingpin.Flag("validate-responses", "Validate responses against the spec and log invalid responses.").
+		BoolVar(&c.ValidateResponses)
+	kingpin.Flag("validate-responses-strict", "Respond with 500 if a response doesn't match the spec. Requires --validate-responses.").
+		BoolVar(&c.ValidateResponsesStrict)
+	kingpin.Flag("metrics", "Enable Prometheus metrics.").
+		BoolVar(&c.Metrics)
+	kingpin.Flag("metrics-path", "Path to serve Prometheus metrics on.").
+		Default(defaultMetricsPath).StringVar(&c.MetricsPath)
+	kingpin.Flag("read-timeout", "Timeout for reading the request.").
+		Default(defaultReadTimeout.String()).DurationVar(&c.ReadTimeout)
+	kingpin.Flag("write-timeout", "Timeout for writing the response.").
+		Default(defaultWriteTimeout.String()).DurationVar(&c.WriteTimeout)
+	kingpin.Flag("read-header-timeout", "Timeout for reading the request headers, the read timeout if not set.").
+		DurationVar(&c.ReadHeaderTimeout)
+	kingpin.Flag("idle-timeout", "Timeout for the next request of a keep-alive connection, the read timeout if not set.").
+		DurationVar(&c.IdleTimeout)
+	kingpin.Flag("max-header-bytes", "Maximum size of the request headers in bytes.").
+		IntVar(&c.MaxHeaderBytes)
+	kingpin.Flag("max-body-bytes", "Maximum size of the request bodies in bytes.").
+		Int64Var(&c.MaxBodyBytes)
+	kingpin.Flag("request-timeout", "Deadline of the requests of operations without an x-gin-timeout.").
+		DurationVar(&c.RequestTimeout)
+	kingpin.Flag("shutdown-drain-period", "Time to report unhealthy while still serving requests before shutting down.").
+		DurationVar(&c.ShutdownDrainPeriod)
+	kingpin.Flag("shutdown-timeout", "Maximum time to wait for active requests and for the shutdown hooks when shutting down.").
+		Default(defaultShutdownTimeout.String()).DurationVar(&c.ShutdownTimeout)
+	kingpin.Flag("rate-limit", "Number of requests per rate limit period of operations without an x-rate-limit.").
+		IntVar(&c.RateLimit.Requests)
+	kingpin.Flag("rate-limit-period", "Period of the rate limit.").
+		Default(defaultRateLimitPeriod.String()).DurationVar(&c.RateLimit.Per)
+	kingpin.Flag("rate-limit-key", "Key the requests are rate limited by, ip or principal.").
+		Default(string(middleware.RateLimitKeyIP)).EnumVar((*string)(&c.RateLimit.Key), string(middleware.RateLimitKeyIP), string(middleware.RateLimitKeyPrincipal))
+	kingpin.Flag("cors-allowed-origin", "Origin allowed to call the API from a browser, or * for any origin. Repeat for multiple origins.").
+		StringsVar(&c.CORS.AllowedOrigins)
+	kingpin.Flag("cors-allow-credentials", "Allow CORS requests with credentials.").
+		BoolVar(&c.CORS.AllowCredentials)
+	kingpin.Flag("cors-max-age", "Time the responses to CORS preflight requests may be cached.").
+		DurationVar(&c.CORS.MaxAge)
+	kingpin.Flag("idempotency", "Enable Idempotency-Key support of POST and PATCH operations without an x-idempotent.").
+		BoolVar(&c.Idempotency)
+	kingpin.Flag("etags", "Compute entity tags of the responses of GET operations for conditional requests.").
+		BoolVar(&c.ETags)
 
 	return c
 }
@@ -318,6 +318,13 @@ func init() {
                   }
                 }
               }
+            },
+            "headers": {
+              "X-Total-Count": {
+                "type": "integer",
+                "format": "int64",
+                "description": "Total number of clusters matching the filters."
+              }
             }
           },
           "401": {
@@ -332,6 +339,12 @@ func init() {
               "$ref": "#/definitions/Error"
             }
           }
+        },
+        "x-gin-timeout": "30s",
+        "x-rate-limit": {
+          "key": "principal",
+          "per": "1m",
+          "requests": 100
         }
       },
       "post": {
@@ -357,6 +370,13 @@ func init() {
             "description": "The cluster creation request is accepted",
             "schema": {
               "$ref": "#/definitions/Cluster"
+            },
+            "headers": {
+              "Location": {
+                "type": "string",
+                "format": "uri",
+                "description": "URL of the created cluster."
+              }
             }
           },
           "400": {
@@ -380,7 +400,8 @@ func init() {
               "$ref": "#/definitions/Error"
             }
           }
-        }
+        },
+        "x-idempotent": true
       }
     },
     "/kubernetes-clusters/{cluster_id}": {
@@ -512,7 +533,8 @@ func init() {
               "$ref": "#/definitions/Error"
             }
           }
-        }
+        },
+        "x-if-match": "optional"
       }
     },
     "/kubernetes-clusters/{cluster_id}/config-items/{config_key}": {
@@ -685,6 +707,12 @@ func init() {
               "$ref": "#/definitions/NodePool"
             }
           },
+          "201": {
+            "description": "The node pool is created.",
+            "schema": {
+              "$ref": "#/definitions/NodePool"
+            }
+          },
           "400": {
             "description": "Invalid request",
             "schema": {
@@ -1477,6 +1505,14 @@ func init() {
                   }
                 }
               }
+            },
+            "headers": {
+              "X-Total-Count": {
+                "minimum": 0,
+                "type": "integer",
+                "format": "int64",
+                "description": "Total number of clusters matching the filters."
+              }
             }
           },
           "401": {
@@ -1491,6 +1527,12 @@ func init() {
               "$ref": "#/definitions/Error"
             }
           }
+        },
+        "x-gin-timeout": "30s",
+        "x-rate-limit": {
+          "key": "principal",
+          "per": "1m",
+          "requests": 100
         }
       },
       "post": {
@@ -1516,6 +1558,13 @@ func init() {
             "description": "The cluster creation request is accepted",
             "schema": {
               "$ref": "#/definitions/Cluster"
+            },
+            "headers": {
+              "Location": {
+                "type": "string",
+                "format": "uri",
+                "description": "URL of the created cluster."
+              }
             }
           },
           "400": {
@@ -1539,7 +1588,8 @@ func init() {
               "$ref": "#/definitions/Error"
             }
           }
-        }
+        },
+        "x-idempotent": true
       }
     },
     "/kubernetes-clusters/{cluster_id}": {
@@ -1686,7 +1736,8 @@ func init() {
               "$ref": "#/definitions/Error"
             }
           }
-        }
+        },
+        "x-if-match": "optional"
       }
     },
     "/kubernetes-clusters/{cluster_id}/config-items/{config_key}": {
@@ -1894,6 +1945,12 @@ func init() {
               "$ref": "#/definitions/NodePool"
             }
           },
+          "201": {
+            "description": "The node pool is created.",
+            "schema": {
+              "$ref": "#/definitions/NodePool"
+            }
+          },
           "400": {
             "description": "Invalid request",
             "schema": {
@@ -11,6 +11,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/mikkeloscar/gin-swagger/api"
+	"github.com/mikkeloscar/gin-swagger/middleware"
 	"github.com/mikkeloscar/gin-swagger/tracing"
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
@@ -25,7 +26,7 @@ import (
 
 // CreateClusterEndpoint executes the core logic of the related
 // route endpoint.
-func CreateClusterEndpoint(handler func(ctx *gin.Context, params *CreateClusterParams) *api.Response) gin.HandlerFunc {
+func CreateClusterEndpoint(handler func(ctx *gin.Context, params *CreateClusterParams) CreateClusterResponder) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		span := opentracing.SpanFromContext(tracing.Context(ctx))
 
@@ -39,12 +40,8 @@ func CreateClusterEndpoint(handler func(ctx *gin.Context, params *CreateClusterP
 		params := NewCreateClusterParams()
 		err := params.readRequest(ctx)
 		if err != nil {
-			errObj := err.(*errors.CompositeError)
-			problem := api.Problem{
-				Title:  "Unprocessable Entity.",
-				Status: int(errObj.Code()),
-				Detail: errObj.Error(),
-			}
+			problem := middleware.ValidationProblem(err)
+			_ = ctx.Error(err).SetType(gin.ErrorTypePublic)
 
 			// attach tags to opentracing span
 			if span != nil {
@@ -56,19 +53,33 @@ func CreateClusterEndpoint(handler func(ctx *gin.Context, params *CreateClusterP
 			return
 		}
 
-		resp := handler(ctx, params)
+		responder := handler(ctx, params)
+		if responder == nil {
+			// attach tags to opentracing span
+			if span != nil {
+				ext.HTTPStatusCode.Set(span, uint16(http.StatusInternalServerError))
+			}
+
+			middleware.WriteResponse(ctx, &api.Response{
+				Code: http.StatusInternalServerError,
+				Body: api.Problem{
+					Title:  "Internal Server Error.",
+					Status: http.StatusInternalServerError,
+					Detail: "CreateCluster returned no response",
+				},
+			})
+			return
+		}
+
+		resp := responder.Response()
+		resp = middleware.ValidateResponse(ctx, resp)
 
 		// attach tags to opentracing span
 		if span != nil {
 			ext.HTTPStatusCode.Set(span, uint16(resp.Code))
 		}
 
-		switch resp.Code {
-		case http.StatusNoContent:
-			ctx.AbortWithStatus(resp.Code)
-		default:
-			ctx.JSON(resp.Code, resp.Body)
-		}
+		middleware.WriteResponse(ctx, resp)
 	}
 }
 
@@ -100,7 +111,7 @@ func (o *CreateClusterParams) readRequest(ctx *gin.Context) error {
 
 	if runtime.HasBody(ctx.Request) {
 		var body models.Cluster
-		if err := ctx.BindJSON(&body); err != nil {
+		if err := middleware.BindBody(ctx, &body); err != nil {
 			if err == io.EOF {
 				res = append(res, errors.Required("cluster", "body", ""))
 			} else {
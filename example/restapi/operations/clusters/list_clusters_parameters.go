@@ -10,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/mikkeloscar/gin-swagger/api"
+	"github.com/mikkeloscar/gin-swagger/middleware"
 	"github.com/mikkeloscar/gin-swagger/tracing"
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
@@ -24,7 +25,7 @@ import (
 
 // ListClustersEndpoint executes the core logic of the related
 // route endpoint.
-func ListClustersEndpoint(handler func(ctx *gin.Context, params *ListClustersParams) *api.Response) gin.HandlerFunc {
+func ListClustersEndpoint(handler func(ctx *gin.Context, params *ListClustersParams) ListClustersResponder) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		span := opentracing.SpanFromContext(tracing.Context(ctx))
 
@@ -38,12 +39,8 @@ func ListClustersEndpoint(handler func(ctx *gin.Context, params *ListClustersPar
 		params := NewListClustersParams()
 		err := params.readRequest(ctx)
 		if err != nil {
-			errObj := err.(*errors.CompositeError)
-			problem := api.Problem{
-				Title:  "Unprocessable Entity.",
-				Status: int(errObj.Code()),
-				Detail: errObj.Error(),
-			}
+			problem := middleware.ValidationProblem(err)
+			_ = ctx.Error(err).SetType(gin.ErrorTypePublic)
 
 			// attach tags to opentracing span
 			if span != nil {
@@ -55,19 +52,33 @@ func ListClustersEndpoint(handler func(ctx *gin.Context, params *ListClustersPar
 			return
 		}
 
-		resp := handler(ctx, params)
+		responder := handler(ctx, params)
+		if responder == nil {
+			// attach tags to opentracing span
+			if span != nil {
+				ext.HTTPStatusCode.Set(span, uint16(http.StatusInternalServerError))
+			}
+
+			middleware.WriteResponse(ctx, &api.Response{
+				Code: http.StatusInternalServerError,
+				Body: api.Problem{
+					Title:  "Internal Server Error.",
+					Status: http.StatusInternalServerError,
+					Detail: "ListClusters returned no response",
+				},
+			})
+			return
+		}
+
+		resp := responder.Response()
+		resp = middleware.ValidateResponse(ctx, resp)
 
 		// attach tags to opentracing span
 		if span != nil {
 			ext.HTTPStatusCode.Set(span, uint16(resp.Code))
 		}
 
-		switch resp.Code {
-		case http.StatusNoContent:
-			ctx.AbortWithStatus(resp.Code)
-		default:
-			ctx.JSON(resp.Code, resp.Body)
-		}
+		middleware.WriteResponse(ctx, resp)
 	}
 }
 
@@ -0,0 +1,93 @@
+// Code generated by gin-swagger; DO NOT EDIT.
+
+package clusters
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ginclient "github.com/mikkeloscar/gin-swagger/client"
+)
+
+// NewListClustersRequest creates the HTTP request for the
+// list clusters operation against the service at baseURL. The params are
+// not validated, this is left to the service.
+func NewListClustersRequest(ctx context.Context, baseURL string, params *ListClustersParams) (*http.Request, error) {
+	if params == nil {
+		params = NewListClustersParams()
+	}
+
+	urlPath := "/kubernetes-clusters"
+	query := url.Values{}
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+	var body io.Reader
+
+	if params.Alias != nil {
+		query.Set("alias", ginclient.FormatValue(*params.Alias))
+	}
+
+	if params.APIServerURL != nil {
+		query.Set("api_server_url", ginclient.FormatValue(*params.APIServerURL))
+	}
+
+	if params.Channel != nil {
+		query.Set("channel", ginclient.FormatValue(*params.Channel))
+	}
+
+	if params.CriticalityLevel != nil {
+		query.Set("criticality_level", ginclient.FormatValue(*params.CriticalityLevel))
+	}
+
+	if params.Environment != nil {
+		query.Set("environment", ginclient.FormatValue(*params.Environment))
+	}
+
+	if params.InfrastructureAccount != nil {
+		query.Set("infrastructure_account", ginclient.FormatValue(*params.InfrastructureAccount))
+	}
+
+	if params.LifecycleStatus != nil {
+		query.Set("lifecycle_status", ginclient.FormatValue(*params.LifecycleStatus))
+	}
+
+	if params.LocalID != nil {
+		query.Set("local_id", ginclient.FormatValue(*params.LocalID))
+	}
+
+	if params.Provider != nil {
+		query.Set("provider", ginclient.FormatValue(*params.Provider))
+	}
+
+	if params.Region != nil {
+		query.Set("region", ginclient.FormatValue(*params.Region))
+	}
+
+	if params.Verbose != nil {
+		query.Set("verbose", ginclient.FormatValue(*params.Verbose))
+	}
+
+	u := strings.TrimSuffix(baseURL, "/") + urlPath
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	return req, nil
+}
+
+// vim: ft=go
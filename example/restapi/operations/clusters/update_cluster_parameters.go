@@ -11,6 +11,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/mikkeloscar/gin-swagger/api"
+	"github.com/mikkeloscar/gin-swagger/middleware"
 	"github.com/mikkeloscar/gin-swagger/tracing"
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
@@ -26,7 +27,9 @@ import (
 
 // UpdateClusterEndpoint executes the core logic of the related
 // route endpoint.
-func UpdateClusterEndpoint(handler func(ctx *gin.Context, params *UpdateClusterParams) *api.Response) gin.HandlerFunc {
+// The If-Match header of the request is evaluated against the entity tag
+// returned by etagHandler before the handler is called.
+func UpdateClusterEndpoint(handler func(ctx *gin.Context, params *UpdateClusterParams) UpdateClusterResponder, etagHandler func(ctx *gin.Context, params *UpdateClusterParams) (string, error)) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		span := opentracing.SpanFromContext(tracing.Context(ctx))
 
@@ -40,13 +43,34 @@ func UpdateClusterEndpoint(handler func(ctx *gin.Context, params *UpdateClusterP
 		params := NewUpdateClusterParams()
 		err := params.readRequest(ctx)
 		if err != nil {
-			errObj := err.(*errors.CompositeError)
-			problem := api.Problem{
-				Title:  "Unprocessable Entity.",
-				Status: int(errObj.Code()),
-				Detail: errObj.Error(),
+			problem := middleware.ValidationProblem(err)
+			_ = ctx.Error(err).SetType(gin.ErrorTypePublic)
+
+			// attach tags to opentracing span
+			if span != nil {
+				ext.HTTPStatusCode.Set(span, uint16(problem.Status))
 			}
 
+			ctx.Writer.Header().Set("Content-Type", "application/problem+json")
+			ctx.JSON(problem.Status, problem)
+			return
+		}
+
+		// evaluate the If-Match header against the current entity tag of the
+		// resource before modifying it.
+		var problem *api.Problem
+		etag, err := etagHandler(ctx, params)
+		if err != nil {
+			_ = ctx.Error(err)
+			problem = &api.Problem{
+				Title:  "Internal Server Error.",
+				Status: http.StatusInternalServerError,
+				Detail: "failed to get the entity tag of the resource",
+			}
+		} else {
+			problem = middleware.PreconditionProblem(ctx, etag, false)
+		}
+		if problem != nil {
 			// attach tags to opentracing span
 			if span != nil {
 				ext.HTTPStatusCode.Set(span, uint16(problem.Status))
@@ -57,19 +81,33 @@ func UpdateClusterEndpoint(handler func(ctx *gin.Context, params *UpdateClusterP
 			return
 		}
 
-		resp := handler(ctx, params)
+		responder := handler(ctx, params)
+		if responder == nil {
+			// attach tags to opentracing span
+			if span != nil {
+				ext.HTTPStatusCode.Set(span, uint16(http.StatusInternalServerError))
+			}
+
+			middleware.WriteResponse(ctx, &api.Response{
+				Code: http.StatusInternalServerError,
+				Body: api.Problem{
+					Title:  "Internal Server Error.",
+					Status: http.StatusInternalServerError,
+					Detail: "UpdateCluster returned no response",
+				},
+			})
+			return
+		}
+
+		resp := responder.Response()
+		resp = middleware.ValidateResponse(ctx, resp)
 
 		// attach tags to opentracing span
 		if span != nil {
 			ext.HTTPStatusCode.Set(span, uint16(resp.Code))
 		}
 
-		switch resp.Code {
-		case http.StatusNoContent:
-			ctx.AbortWithStatus(resp.Code)
-		default:
-			ctx.JSON(resp.Code, resp.Body)
-		}
+		middleware.WriteResponse(ctx, resp)
 	}
 }
 
@@ -97,6 +135,10 @@ type UpdateClusterParams struct {
 	  In: path
 	*/
 	ClusterID string
+
+	// IfMatch is the If-Match header of the request, the entity tag the
+	// resource must have for the request to succeed, e.g. "v1".
+	IfMatch string
 }
 
 // readRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -105,9 +147,11 @@ func (o *UpdateClusterParams) readRequest(ctx *gin.Context) error {
 	var res []error
 	formats := strfmt.NewFormats()
 
+	o.IfMatch = ctx.GetHeader("If-Match")
+
 	if runtime.HasBody(ctx.Request) {
 		var body models.ClusterUpdate
-		if err := ctx.BindJSON(&body); err != nil {
+		if err := middleware.BindBody(ctx, &body); err != nil {
 			if err == io.EOF {
 				res = append(res, errors.Required("cluster", "body", ""))
 			} else {
@@ -0,0 +1,64 @@
+// Code generated by gin-swagger; DO NOT EDIT.
+
+package clusters
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ginclient "github.com/mikkeloscar/gin-swagger/client"
+)
+
+// NewUpdateClusterRequest creates the HTTP request for the
+// update cluster operation against the service at baseURL. The params are
+// not validated, this is left to the service.
+func NewUpdateClusterRequest(ctx context.Context, baseURL string, params *UpdateClusterParams) (*http.Request, error) {
+	if params == nil {
+		params = NewUpdateClusterParams()
+	}
+
+	urlPath := "/kubernetes-clusters/{cluster_id}"
+	query := url.Values{}
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+	var body io.Reader
+
+	contentType := "application/json"
+	data := &bytes.Buffer{}
+	if err := ginclient.EncodeBody(data, contentType, params.Cluster); err != nil {
+		return nil, err
+	}
+	body = data
+	header.Set("Content-Type", contentType)
+
+	urlPath = strings.Replace(urlPath, "{cluster_id}", url.PathEscape(ginclient.FormatValue(params.ClusterID)), 1)
+
+	if params.IfMatch != "" {
+		header.Set("If-Match", params.IfMatch)
+	}
+
+	u := strings.TrimSuffix(baseURL, "/") + urlPath
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PATCH", u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	return req, nil
+}
+
+// vim: ft=go
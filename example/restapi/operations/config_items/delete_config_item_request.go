@@ -0,0 +1,53 @@
+// Code generated by gin-swagger; DO NOT EDIT.
+
+package config_items
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ginclient "github.com/mikkeloscar/gin-swagger/client"
+)
+
+// NewDeleteConfigItemRequest creates the HTTP request for the
+// delete config item operation against the service at baseURL. The params are
+// not validated, this is left to the service.
+func NewDeleteConfigItemRequest(ctx context.Context, baseURL string, params *DeleteConfigItemParams) (*http.Request, error) {
+	if params == nil {
+		params = NewDeleteConfigItemParams()
+	}
+
+	urlPath := "/kubernetes-clusters/{cluster_id}/config-items/{config_key}"
+	query := url.Values{}
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+	var body io.Reader
+
+	urlPath = strings.Replace(urlPath, "{cluster_id}", url.PathEscape(ginclient.FormatValue(params.ClusterID)), 1)
+
+	urlPath = strings.Replace(urlPath, "{config_key}", url.PathEscape(ginclient.FormatValue(params.ConfigKey)), 1)
+
+	u := strings.TrimSuffix(baseURL, "/") + urlPath
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	return req, nil
+}
+
+// vim: ft=go
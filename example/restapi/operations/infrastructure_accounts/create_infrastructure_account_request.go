@@ -0,0 +1,58 @@
+// Code generated by gin-swagger; DO NOT EDIT.
+
+package infrastructure_accounts
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ginclient "github.com/mikkeloscar/gin-swagger/client"
+)
+
+// NewCreateInfrastructureAccountRequest creates the HTTP request for the
+// create infrastructure account operation against the service at baseURL. The params are
+// not validated, this is left to the service.
+func NewCreateInfrastructureAccountRequest(ctx context.Context, baseURL string, params *CreateInfrastructureAccountParams) (*http.Request, error) {
+	if params == nil {
+		params = NewCreateInfrastructureAccountParams()
+	}
+
+	urlPath := "/infrastructure-accounts"
+	query := url.Values{}
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+	var body io.Reader
+
+	contentType := "application/json"
+	data := &bytes.Buffer{}
+	if err := ginclient.EncodeBody(data, contentType, params.InfrastructureAccount); err != nil {
+		return nil, err
+	}
+	body = data
+	header.Set("Content-Type", contentType)
+
+	u := strings.TrimSuffix(baseURL, "/") + urlPath
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	return req, nil
+}
+
+// vim: ft=go
@@ -0,0 +1,51 @@
+// Code generated by gin-swagger; DO NOT EDIT.
+
+package infrastructure_accounts
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ginclient "github.com/mikkeloscar/gin-swagger/client"
+)
+
+// NewGetInfrastructureAccountRequest creates the HTTP request for the
+// get infrastructure account operation against the service at baseURL. The params are
+// not validated, this is left to the service.
+func NewGetInfrastructureAccountRequest(ctx context.Context, baseURL string, params *GetInfrastructureAccountParams) (*http.Request, error) {
+	if params == nil {
+		params = NewGetInfrastructureAccountParams()
+	}
+
+	urlPath := "/infrastructure-accounts/{account_id}"
+	query := url.Values{}
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+	var body io.Reader
+
+	urlPath = strings.Replace(urlPath, "{account_id}", url.PathEscape(ginclient.FormatValue(params.AccountID)), 1)
+
+	u := strings.TrimSuffix(baseURL, "/") + urlPath
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	return req, nil
+}
+
+// vim: ft=go
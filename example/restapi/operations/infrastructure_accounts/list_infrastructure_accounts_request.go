@@ -0,0 +1,43 @@
+// Code generated by gin-swagger; DO NOT EDIT.
+
+package infrastructure_accounts
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// NewListInfrastructureAccountsRequest creates the HTTP request for the
+// list infrastructure accounts operation against the service at baseURL.
+func NewListInfrastructureAccountsRequest(ctx context.Context, baseURL string) (*http.Request, error) {
+
+	urlPath := "/infrastructure-accounts"
+	query := url.Values{}
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+	var body io.Reader
+
+	u := strings.TrimSuffix(baseURL, "/") + urlPath
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	return req, nil
+}
+
+// vim: ft=go
@@ -11,6 +11,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/mikkeloscar/gin-swagger/api"
+	"github.com/mikkeloscar/gin-swagger/middleware"
 	"github.com/mikkeloscar/gin-swagger/tracing"
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
@@ -26,7 +27,7 @@ import (
 
 // UpdateInfrastructureAccountEndpoint executes the core logic of the related
 // route endpoint.
-func UpdateInfrastructureAccountEndpoint(handler func(ctx *gin.Context, params *UpdateInfrastructureAccountParams) *api.Response) gin.HandlerFunc {
+func UpdateInfrastructureAccountEndpoint(handler func(ctx *gin.Context, params *UpdateInfrastructureAccountParams) UpdateInfrastructureAccountResponder) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		span := opentracing.SpanFromContext(tracing.Context(ctx))
 
@@ -40,12 +41,8 @@ func UpdateInfrastructureAccountEndpoint(handler func(ctx *gin.Context, params *
 		params := NewUpdateInfrastructureAccountParams()
 		err := params.readRequest(ctx)
 		if err != nil {
-			errObj := err.(*errors.CompositeError)
-			problem := api.Problem{
-				Title:  "Unprocessable Entity.",
-				Status: int(errObj.Code()),
-				Detail: errObj.Error(),
-			}
+			problem := middleware.ValidationProblem(err)
+			_ = ctx.Error(err).SetType(gin.ErrorTypePublic)
 
 			// attach tags to opentracing span
 			if span != nil {
@@ -57,19 +54,33 @@ func UpdateInfrastructureAccountEndpoint(handler func(ctx *gin.Context, params *
 			return
 		}
 
-		resp := handler(ctx, params)
+		responder := handler(ctx, params)
+		if responder == nil {
+			// attach tags to opentracing span
+			if span != nil {
+				ext.HTTPStatusCode.Set(span, uint16(http.StatusInternalServerError))
+			}
+
+			middleware.WriteResponse(ctx, &api.Response{
+				Code: http.StatusInternalServerError,
+				Body: api.Problem{
+					Title:  "Internal Server Error.",
+					Status: http.StatusInternalServerError,
+					Detail: "UpdateInfrastructureAccount returned no response",
+				},
+			})
+			return
+		}
+
+		resp := responder.Response()
+		resp = middleware.ValidateResponse(ctx, resp)
 
 		// attach tags to opentracing span
 		if span != nil {
 			ext.HTTPStatusCode.Set(span, uint16(resp.Code))
 		}
 
-		switch resp.Code {
-		case http.StatusNoContent:
-			ctx.AbortWithStatus(resp.Code)
-		default:
-			ctx.JSON(resp.Code, resp.Body)
-		}
+		middleware.WriteResponse(ctx, resp)
 	}
 }
 
@@ -112,7 +123,7 @@ func (o *UpdateInfrastructureAccountParams) readRequest(ctx *gin.Context) error
 
 	if runtime.HasBody(ctx.Request) {
 		var body models.InfrastructureAccountUpdate
-		if err := ctx.BindJSON(&body); err != nil {
+		if err := middleware.BindBody(ctx, &body); err != nil {
 			if err == io.EOF {
 				res = append(res, errors.Required("infrastructureAccount", "body", ""))
 			} else {
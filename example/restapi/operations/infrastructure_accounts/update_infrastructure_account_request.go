@@ -0,0 +1,60 @@
+// Code generated by gin-swagger; DO NOT EDIT.
+
+package infrastructure_accounts
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ginclient "github.com/mikkeloscar/gin-swagger/client"
+)
+
+// NewUpdateInfrastructureAccountRequest creates the HTTP request for the
+// update infrastructure account operation against the service at baseURL. The params are
+// not validated, this is left to the service.
+func NewUpdateInfrastructureAccountRequest(ctx context.Context, baseURL string, params *UpdateInfrastructureAccountParams) (*http.Request, error) {
+	if params == nil {
+		params = NewUpdateInfrastructureAccountParams()
+	}
+
+	urlPath := "/infrastructure-accounts/{account_id}"
+	query := url.Values{}
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+	var body io.Reader
+
+	urlPath = strings.Replace(urlPath, "{account_id}", url.PathEscape(ginclient.FormatValue(params.AccountID)), 1)
+
+	contentType := "application/json"
+	data := &bytes.Buffer{}
+	if err := ginclient.EncodeBody(data, contentType, params.InfrastructureAccount); err != nil {
+		return nil, err
+	}
+	body = data
+	header.Set("Content-Type", contentType)
+
+	u := strings.TrimSuffix(baseURL, "/") + urlPath
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PATCH", u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	return req, nil
+}
+
+// vim: ft=go
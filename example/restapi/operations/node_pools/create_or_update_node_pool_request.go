@@ -0,0 +1,62 @@
+// Code generated by gin-swagger; DO NOT EDIT.
+
+package node_pools
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ginclient "github.com/mikkeloscar/gin-swagger/client"
+)
+
+// NewCreateOrUpdateNodePoolRequest creates the HTTP request for the
+// create or update node pool operation against the service at baseURL. The params are
+// not validated, this is left to the service.
+func NewCreateOrUpdateNodePoolRequest(ctx context.Context, baseURL string, params *CreateOrUpdateNodePoolParams) (*http.Request, error) {
+	if params == nil {
+		params = NewCreateOrUpdateNodePoolParams()
+	}
+
+	urlPath := "/kubernetes-clusters/{cluster_id}/node-pools/{node_pool_name}"
+	query := url.Values{}
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+	var body io.Reader
+
+	urlPath = strings.Replace(urlPath, "{cluster_id}", url.PathEscape(ginclient.FormatValue(params.ClusterID)), 1)
+
+	contentType := "application/json"
+	data := &bytes.Buffer{}
+	if err := ginclient.EncodeBody(data, contentType, params.NodePool); err != nil {
+		return nil, err
+	}
+	body = data
+	header.Set("Content-Type", contentType)
+
+	urlPath = strings.Replace(urlPath, "{node_pool_name}", url.PathEscape(ginclient.FormatValue(params.NodePoolName)), 1)
+
+	u := strings.TrimSuffix(baseURL, "/") + urlPath
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	return req, nil
+}
+
+// vim: ft=go
@@ -3,15 +3,20 @@ package main
 import (
 	"net/http"
 
+	"github.com/mikkeloscar/gin-swagger/example/models"
 	"github.com/mikkeloscar/gin-swagger/example/restapi/operations/clusters"
 	"github.com/mikkeloscar/gin-swagger/example/restapi/operations/config_items"
 	"github.com/mikkeloscar/gin-swagger/example/restapi/operations/infrastructure_accounts"
 	"github.com/mikkeloscar/gin-swagger/example/restapi/operations/node_pools"
 
 	"github.com/gin-gonic/gin"
-	"github.com/mikkeloscar/gin-swagger/api"
 )
 
+var notImplemented = &models.Error{
+	Code:    http.StatusNotImplemented,
+	Message: "Not Implemented",
+}
+
 type ExampleService struct {
 	Health bool
 }
@@ -19,48 +24,48 @@ type ExampleService struct {
 func (s *ExampleService) Healthy() bool {
 	return s.Health
 }
-func (s *ExampleService) AddOrUpdateConfigItem(ctx *gin.Context, params *config_items.AddOrUpdateConfigItemParams) *api.Response {
-	return &api.Response{Code: http.StatusNotImplemented, Body: "Not Implemented"}
+func (s *ExampleService) AddOrUpdateConfigItem(ctx *gin.Context, params *config_items.AddOrUpdateConfigItemParams) config_items.AddOrUpdateConfigItemResponder {
+	return config_items.NewAddOrUpdateConfigItemInternalServerError(notImplemented)
 }
-func (s *ExampleService) CreateCluster(ctx *gin.Context, params *clusters.CreateClusterParams) *api.Response {
-	return &api.Response{Code: http.StatusNotImplemented, Body: "Not Implemented"}
+func (s *ExampleService) CreateCluster(ctx *gin.Context, params *clusters.CreateClusterParams) clusters.CreateClusterResponder {
+	return clusters.NewCreateClusterInternalServerError(notImplemented)
 }
-func (s *ExampleService) CreateInfrastructureAccount(ctx *gin.Context, params *infrastructure_accounts.CreateInfrastructureAccountParams) *api.Response {
-	return &api.Response{Code: http.StatusNotImplemented, Body: "Not Implemented"}
+func (s *ExampleService) CreateInfrastructureAccount(ctx *gin.Context, params *infrastructure_accounts.CreateInfrastructureAccountParams) infrastructure_accounts.CreateInfrastructureAccountResponder {
+	return infrastructure_accounts.NewCreateInfrastructureAccountInternalServerError(notImplemented)
 }
-func (s *ExampleService) CreateOrUpdateNodePool(ctx *gin.Context, params *node_pools.CreateOrUpdateNodePoolParams) *api.Response {
-	return &api.Response{Code: http.StatusNotImplemented, Body: "Not Implemented"}
+func (s *ExampleService) CreateOrUpdateNodePool(ctx *gin.Context, params *node_pools.CreateOrUpdateNodePoolParams) node_pools.CreateOrUpdateNodePoolResponder {
+	return node_pools.NewCreateOrUpdateNodePoolInternalServerError(notImplemented)
 }
-func (s *ExampleService) DeleteCluster(ctx *gin.Context, params *clusters.DeleteClusterParams) *api.Response {
-	return &api.Response{Code: http.StatusNotImplemented, Body: "Not Implemented"}
+func (s *ExampleService) DeleteCluster(ctx *gin.Context, params *clusters.DeleteClusterParams) clusters.DeleteClusterResponder {
+	return clusters.NewDeleteClusterInternalServerError(notImplemented)
 }
-func (s *ExampleService) DeleteConfigItem(ctx *gin.Context, params *config_items.DeleteConfigItemParams) *api.Response {
-	return &api.Response{Code: http.StatusNotImplemented, Body: "Not Implemented"}
+func (s *ExampleService) DeleteConfigItem(ctx *gin.Context, params *config_items.DeleteConfigItemParams) config_items.DeleteConfigItemResponder {
+	return config_items.NewDeleteConfigItemInternalServerError(notImplemented)
 }
-func (s *ExampleService) DeleteNodePool(ctx *gin.Context, params *node_pools.DeleteNodePoolParams) *api.Response {
-	return &api.Response{Code: http.StatusNotImplemented, Body: "Not Implemented"}
+func (s *ExampleService) DeleteNodePool(ctx *gin.Context, params *node_pools.DeleteNodePoolParams) node_pools.DeleteNodePoolResponder {
+	return node_pools.NewDeleteNodePoolInternalServerError(notImplemented)
 }
-func (s *ExampleService) GetCluster(ctx *gin.Context, params *clusters.GetClusterParams) *api.Response {
-	return &api.Response{Code: http.StatusNotImplemented, Body: "Not Implemented"}
+func (s *ExampleService) GetCluster(ctx *gin.Context, params *clusters.GetClusterParams) clusters.GetClusterResponder {
+	return clusters.NewGetClusterInternalServerError(notImplemented)
 }
-func (s *ExampleService) GetHealth(ctx *gin.Context) *api.Response {
-	return &api.Response{Code: http.StatusNotImplemented, Body: "Not Implemented"}
+func (s *ExampleService) GetInfrastructureAccount(ctx *gin.Context, params *infrastructure_accounts.GetInfrastructureAccountParams) infrastructure_accounts.GetInfrastructureAccountResponder {
+	return infrastructure_accounts.NewGetInfrastructureAccountInternalServerError(notImplemented)
 }
-func (s *ExampleService) GetInfrastructureAccount(ctx *gin.Context, params *infrastructure_accounts.GetInfrastructureAccountParams) *api.Response {
-	return &api.Response{Code: http.StatusNotImplemented, Body: "Not Implemented"}
+func (s *ExampleService) ListClusters(ctx *gin.Context, params *clusters.ListClustersParams) clusters.ListClustersResponder {
+	return clusters.NewListClustersInternalServerError(notImplemented)
 }
-func (s *ExampleService) ListClusters(ctx *gin.Context, params *clusters.ListClustersParams) *api.Response {
-	return &api.Response{Code: http.StatusNotImplemented, Body: "Not Implemented"}
+func (s *ExampleService) ListInfrastructureAccounts(ctx *gin.Context) infrastructure_accounts.ListInfrastructureAccountsResponder {
+	return infrastructure_accounts.NewListInfrastructureAccountsInternalServerError(notImplemented)
 }
-func (s *ExampleService) ListInfrastructureAccounts(ctx *gin.Context) *api.Response {
-	return &api.Response{Code: http.StatusNotImplemented, Body: "Not Implemented"}
+func (s *ExampleService) ListNodePools(ctx *gin.Context, params *node_pools.ListNodePoolsParams) node_pools.ListNodePoolsResponder {
+	return node_pools.NewListNodePoolsInternalServerError(notImplemented)
 }
-func (s *ExampleService) ListNodePools(ctx *gin.Context, params *node_pools.ListNodePoolsParams) *api.Response {
-	return &api.Response{Code: http.StatusNotImplemented, Body: "Not Implemented"}
+func (s *ExampleService) UpdateCluster(ctx *gin.Context, params *clusters.UpdateClusterParams) clusters.UpdateClusterResponder {
+	return clusters.NewUpdateClusterInternalServerError(notImplemented)
 }
-func (s *ExampleService) UpdateCluster(ctx *gin.Context, params *clusters.UpdateClusterParams) *api.Response {
-	return &api.Response{Code: http.StatusNotImplemented, Body: "Not Implemented"}
+func (s *ExampleService) UpdateClusterETag(ctx *gin.Context, params *clusters.UpdateClusterParams) (string, error) {
+	return "", nil
 }
-func (s *ExampleService) UpdateInfrastructureAccount(ctx *gin.Context, params *infrastructure_accounts.UpdateInfrastructureAccountParams) *api.Response {
-	return &api.Response{Code: http.StatusNotImplemented, Body: "Not Implemented"}
+func (s *ExampleService) UpdateInfrastructureAccount(ctx *gin.Context, params *infrastructure_accounts.UpdateInfrastructureAccountParams) infrastructure_accounts.UpdateInfrastructureAccountResponder {
+	return infrastructure_accounts.NewUpdateInfrastructureAccountInternalServerError(notImplemented)
 }
@@ -0,0 +1,444 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	ginclient "github.com/mikkeloscar/gin-swagger/client"
+	"github.com/mikkeloscar/gin-swagger/example/client"
+	"github.com/mikkeloscar/gin-swagger/example/models"
+	"github.com/mikkeloscar/gin-swagger/example/restapi"
+	"github.com/mikkeloscar/gin-swagger/example/restapi/operations/clusters"
+	"github.com/mikkeloscar/gin-swagger/example/restapi/operations/node_pools"
+	"github.com/mikkeloscar/gin-swagger/example/restapitest"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestGetCluster(t *testing.T) {
+	for _, tc := range []struct {
+		msg        string
+		clusterID  string
+		statusCode int
+		calls      int
+	}{
+		{
+			msg:        "valid cluster id",
+			clusterID:  "aws:123456789012:eu-central-1:kube-1",
+			statusCode: http.StatusOK,
+			calls:      1,
+		},
+		{
+			msg:        "invalid cluster id",
+			clusterID:  "Kube-1",
+			statusCode: http.StatusUnprocessableEntity,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			svc := &restapitest.Service{
+				GetClusterFunc: func(ctx *gin.Context, params *clusters.GetClusterParams) clusters.GetClusterResponder {
+					return clusters.NewGetClusterOK(&models.Cluster{ID: &params.ClusterID})
+				},
+			}
+
+			server := restapitest.NewTestServer(svc)
+			defer server.Close()
+
+			req, err := clusters.NewGetClusterRequest(context.Background(), server.URL, &clusters.GetClusterParams{
+				ClusterID: tc.clusterID,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.statusCode {
+				t.Errorf("expected response code %d, got %d", tc.statusCode, resp.StatusCode)
+			}
+
+			calls := svc.GetClusterCalls()
+			if len(calls) != tc.calls {
+				t.Fatalf("expected %d calls, got %d", tc.calls, len(calls))
+			}
+
+			if tc.calls > 0 && calls[0].Params.ClusterID != tc.clusterID {
+				t.Errorf("expected cluster id '%s', got '%s'", tc.clusterID, calls[0].Params.ClusterID)
+			}
+		})
+	}
+}
+
+func TestGetClusterNoResponse(t *testing.T) {
+	svc := &restapitest.Service{
+		GetClusterFunc: func(ctx *gin.Context, params *clusters.GetClusterParams) clusters.GetClusterResponder {
+			return nil
+		},
+	}
+
+	server := restapitest.NewTestServer(svc)
+	defer server.Close()
+
+	req, err := clusters.NewGetClusterRequest(context.Background(), server.URL, &clusters.GetClusterParams{
+		ClusterID: "aws:123456789012:eu-central-1:kube-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected response code %d, got %d", http.StatusInternalServerError, resp.StatusCode)
+	}
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != "application/problem+json" {
+		t.Errorf("expected problem content type, got '%s'", contentType)
+	}
+}
+
+func TestUpdateClusterIfMatch(t *testing.T) {
+	lastModified := time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		msg        string
+		ifMatch    string
+		statusCode int
+		calls      int
+	}{
+		{
+			msg:        "matching entity tag",
+			ifMatch:    `"v1"`,
+			statusCode: http.StatusOK,
+			calls:      1,
+		},
+		{
+			msg:        "mismatching entity tag",
+			ifMatch:    `"v0"`,
+			statusCode: http.StatusPreconditionFailed,
+		},
+		{
+			msg:        "no entity tag",
+			statusCode: http.StatusOK,
+			calls:      1,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			svc := &restapitest.Service{
+				UpdateClusterFunc: func(ctx *gin.Context, params *clusters.UpdateClusterParams) clusters.UpdateClusterResponder {
+					return clusters.NewUpdateClusterOK(&models.Cluster{ID: &params.ClusterID}).
+						WithETag(`"v2"`).
+						WithLastModified(lastModified)
+				},
+				UpdateClusterETagFunc: func(ctx *gin.Context, params *clusters.UpdateClusterParams) (string, error) {
+					return `"v1"`, nil
+				},
+			}
+
+			server := restapitest.NewTestServer(svc)
+			defer server.Close()
+
+			resp, err := client.New(server.URL).UpdateCluster(context.Background(), &clusters.UpdateClusterParams{
+				ClusterID: "kube-1",
+				Cluster:   &models.ClusterUpdate{},
+				IfMatch:   tc.ifMatch,
+			})
+			if tc.statusCode != http.StatusOK {
+				var respErr *ginclient.ResponseError
+				if !errors.As(err, &respErr) || respErr.StatusCode != tc.statusCode {
+					t.Errorf("expected response code %d, got %v", tc.statusCode, err)
+				}
+			} else if err != nil {
+				t.Fatalf("should not fail: %s", err)
+			}
+
+			calls := svc.UpdateClusterCalls()
+			if len(calls) != tc.calls {
+				t.Fatalf("expected %d calls, got %d", tc.calls, len(calls))
+			}
+
+			if tc.calls == 0 {
+				return
+			}
+
+			if calls[0].Params.IfMatch != tc.ifMatch {
+				t.Errorf("expected If-Match '%s', got '%s'", tc.ifMatch, calls[0].Params.IfMatch)
+			}
+
+			if resp.ETag != `"v2"` {
+				t.Errorf("expected ETag '\"v2\"', got '%s'", resp.ETag)
+			}
+
+			if resp.Header.Get("ETag") != resp.ETag {
+				t.Errorf("expected the ETag header '%s', got '%s'", resp.ETag, resp.Header.Get("ETag"))
+			}
+
+			if !resp.LastModified.Equal(lastModified) {
+				t.Errorf("expected last modified %s, got %s", lastModified, resp.LastModified)
+			}
+		})
+	}
+}
+
+func TestRunWithSigHandlerShutdown(t *testing.T) {
+	server := restapi.NewServer(&restapitest.Service{}, &restapi.Config{
+		Address:      "127.0.0.1:0",
+		InsecureHTTP: true,
+		AuthDisabled: true,
+	})
+
+	hookCalled := make(chan struct{})
+	server.OnShutdown(func(context.Context) error {
+		close(hookCalled)
+		return nil
+	})
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.RunWithSigHandler()
+	}()
+
+	// give the server time to start listening.
+	time.Sleep(100 * time.Millisecond)
+
+	err := server.Shutdown()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Errorf("expected no error, got %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected RunWithSigHandler to return after Shutdown")
+	}
+
+	select {
+	case <-hookCalled:
+	default:
+		t.Error("expected shutdown hook to be called")
+	}
+}
+
+func TestListClustersTotalCount(t *testing.T) {
+	for _, tc := range []struct {
+		msg        string
+		totalCount int64
+		statusCode int
+		header     string
+	}{
+		{
+			msg:        "valid header",
+			totalCount: 2,
+			statusCode: http.StatusOK,
+			header:     "2",
+		},
+		{
+			msg:        "header below minimum",
+			totalCount: -1,
+			statusCode: http.StatusInternalServerError,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			svc := &restapitest.Service{
+				ListClustersFunc: func(ctx *gin.Context, params *clusters.ListClustersParams) clusters.ListClustersResponder {
+					return clusters.NewListClustersOK(&clusters.ListClustersOKBody{}).WithXTotalCount(tc.totalCount)
+				},
+			}
+
+			server := restapitest.NewTestServer(svc)
+			defer server.Close()
+
+			req, err := clusters.NewListClustersRequest(context.Background(), server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.statusCode {
+				t.Errorf("expected response code %d, got %d", tc.statusCode, resp.StatusCode)
+			}
+
+			if totalCount := resp.Header.Get("X-Total-Count"); totalCount != tc.header {
+				t.Errorf("expected X-Total-Count '%s', got '%s'", tc.header, totalCount)
+			}
+		})
+	}
+}
+
+func TestCreateOrUpdateNodePoolClient(t *testing.T) {
+	for _, tc := range []struct {
+		msg     string
+		created bool
+	}{
+		{
+			msg: "updated node pool",
+		},
+		{
+			msg:     "created node pool",
+			created: true,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			svc := &restapitest.Service{
+				CreateOrUpdateNodePoolFunc: func(ctx *gin.Context, params *node_pools.CreateOrUpdateNodePoolParams) node_pools.CreateOrUpdateNodePoolResponder {
+					if tc.created {
+						return node_pools.NewCreateOrUpdateNodePoolCreated(params.NodePool)
+					}
+					return node_pools.NewCreateOrUpdateNodePoolOK(params.NodePool)
+				},
+			}
+
+			server := restapitest.NewTestServer(svc)
+			defer server.Close()
+
+			name, discountStrategy, instanceType, profile := "pool-1", "none", "m5.large", "worker-default"
+			resp, err := client.New(server.URL).CreateOrUpdateNodePool(context.Background(), &node_pools.CreateOrUpdateNodePoolParams{
+				ClusterID:    "aws:123456789012:eu-central-1:kube-1",
+				NodePoolName: name,
+				NodePool: &models.NodePool{
+					Name:             &name,
+					DiscountStrategy: &discountStrategy,
+					InstanceType:     &instanceType,
+					Profile:          &profile,
+				},
+			})
+			if err != nil {
+				t.Fatalf("should not fail: %s", err)
+			}
+
+			var nodePool *models.NodePool
+			switch resp := resp.(type) {
+			case *node_pools.CreateOrUpdateNodePoolOK:
+				if tc.created {
+					t.Errorf("expected a created response, got %T", resp)
+				}
+				nodePool = resp.Payload
+			case *node_pools.CreateOrUpdateNodePoolCreated:
+				if !tc.created {
+					t.Errorf("expected an OK response, got %T", resp)
+				}
+				nodePool = resp.Payload
+			default:
+				t.Fatalf("unexpected response %T", resp)
+			}
+
+			if nodePool == nil || nodePool.Name == nil || *nodePool.Name != name {
+				t.Errorf("expected node pool '%s', got %v", name, nodePool)
+			}
+		})
+	}
+}
+
+func TestListClustersClientTotalCount(t *testing.T) {
+	for _, tc := range []struct {
+		msg        string
+		header     string
+		totalCount int64
+		valid      bool
+	}{
+		{
+			msg:        "valid header",
+			header:     "2",
+			totalCount: 2,
+			valid:      true,
+		},
+		{
+			msg:   "no header",
+			valid: true,
+		},
+		{
+			msg:    "header of invalid type",
+			header: "two",
+		},
+		{
+			msg:    "header below minimum",
+			header: "-1",
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tc.header != "" {
+					w.Header().Set("X-Total-Count", tc.header)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"items": []}`))
+			}))
+			defer server.Close()
+
+			resp, err := client.New(server.URL).ListClusters(context.Background(), nil)
+			if !tc.valid {
+				if err == nil {
+					t.Errorf("expected an error for X-Total-Count '%s'", tc.header)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("should not fail: %s", err)
+			}
+
+			if resp.XTotalCount != tc.totalCount {
+				t.Errorf("expected X-Total-Count %d, got %d", tc.totalCount, resp.XTotalCount)
+			}
+		})
+	}
+}
+
+func TestGetClusterSpanName(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	svc := &restapitest.Service{
+		GetClusterFunc: func(ctx *gin.Context, params *clusters.GetClusterParams) clusters.GetClusterResponder {
+			return clusters.NewGetClusterOK(&models.Cluster{ID: &params.ClusterID})
+		},
+	}
+
+	server := restapi.NewServer(svc, &restapi.Config{
+		InsecureHTTP:      true,
+		AuthDisabled:      true,
+		WellKnownDisabled: true,
+		TracerProvider:    provider,
+	})
+	server.ConfigureRoutes()
+
+	ts := httptest.NewServer(server.Routes.Engine)
+	defer ts.Close()
+
+	_, err := client.New(ts.URL).GetCluster(context.Background(), &clusters.GetClusterParams{
+		ClusterID: "aws:123456789012:eu-central-1:kube-1",
+	})
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+
+	if spans[0].Name() != "getCluster" {
+		t.Errorf("expected span name 'getCluster', got '%s'", spans[0].Name())
+	}
+}
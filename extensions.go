@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/go-openapi/analysis"
+	"github.com/go-openapi/loads"
+)
+
+// timeoutExtension is the operation extension setting the deadline of the
+// requests of the operation, e.g. x-gin-timeout: 30s.
+const timeoutExtension = "x-gin-timeout"
+
+// rateLimitExtension is the operation extension limiting the rate of the
+// requests of the operation, e.g.
+// x-rate-limit: {requests: 100, per: 1m, key: principal}.
+const rateLimitExtension = "x-rate-limit"
+
+// idempotentExtension is the operation extension enabling or disabling
+// Idempotency-Key support of the operation, e.g. x-idempotent: true.
+const idempotentExtension = "x-idempotent"
+
+// ifMatchExtension is the operation extension evaluating the If-Match
+// header of the requests of the operation, which is either required or
+// optional, e.g. x-if-match: required.
+const ifMatchExtension = "x-if-match"
+
+// validateExtensions validates the gin-swagger vendor extensions of the
+// operations of the spec at specPath, such that the generated code can rely
+// on them.
+func validateExtensions(specPath string) error {
+	doc, err := loads.Spec(specPath)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for method, paths := range analysis.New(doc.Spec()).Operations() {
+		for path, op := range paths {
+			if value, ok := op.Extensions[timeoutExtension]; ok {
+				err := validateDuration(value)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("invalid %s of %s %s: %w", timeoutExtension, method, path, err))
+				}
+			}
+
+			if value, ok := op.Extensions[rateLimitExtension]; ok {
+				err := validateRateLimit(value)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("invalid %s of %s %s: %w", rateLimitExtension, method, path, err))
+				}
+			}
+
+			if value, ok := op.Extensions[idempotentExtension]; ok {
+				if _, ok := value.(bool); !ok {
+					errs = append(errs, fmt.Errorf("invalid %s of %s %s: expected true or false, got %v", idempotentExtension, method, path, value))
+				}
+			}
+
+			if value, ok := op.Extensions[ifMatchExtension]; ok && value != "required" && value != "optional" {
+				errs = append(errs, fmt.Errorf("invalid %s of %s %s: expected required or optional, got %v", ifMatchExtension, method, path, value))
+			}
+		}
+	}
+
+	// the operations are unordered.
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Error() < errs[j].Error()
+	})
+
+	return errors.Join(errs...)
+}
+
+// validateDuration validates that value is a positive duration string like
+// 30s or 1m.
+func validateDuration(value interface{}) error {
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("expected a duration like 30s, got %v", value)
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+
+	if d <= 0 {
+		return fmt.Errorf("expected a positive duration, got %s", s)
+	}
+	return nil
+}
+
+// validateRateLimit validates that value is a rate limit with a positive
+// integer of requests, a duration per and an optional key of ip or
+// principal.
+func validateRateLimit(value interface{}) error {
+	rate, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("expected an object like {requests: 100, per: 1m}, got %v", value)
+	}
+
+	for field := range rate {
+		if field != "requests" && field != "per" && field != "key" {
+			return fmt.Errorf("unknown field %s", field)
+		}
+	}
+
+	requests, ok := rate["requests"].(float64)
+	if !ok || requests < 1 || requests != math.Trunc(requests) {
+		return fmt.Errorf("expected requests to be a positive integer, got %v", rate["requests"])
+	}
+
+	err := validateDuration(rate["per"])
+	if err != nil {
+		return fmt.Errorf("invalid per: %w", err)
+	}
+
+	if key, ok := rate["key"]; ok && key != "ip" && key != "principal" {
+		return fmt.Errorf("expected key to be ip or principal, got %v", key)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateExtensions(t *testing.T) {
+	for _, tc := range []struct {
+		msg        string
+		extensions string
+		valid      bool
+	}{
+		{
+			msg:        "valid timeout",
+			extensions: `"x-gin-timeout": "30s"`,
+			valid:      true,
+		},
+		{
+			msg:        "invalid timeout",
+			extensions: `"x-gin-timeout": "30 seconds"`,
+		},
+		{
+			msg:        "negative timeout",
+			extensions: `"x-gin-timeout": "-1s"`,
+		},
+		{
+			msg:        "timeout not a string",
+			extensions: `"x-gin-timeout": 30`,
+		},
+		{
+			msg:        "valid rate limit",
+			extensions: `"x-rate-limit": {"requests": 100, "per": "1m", "key": "principal"}`,
+			valid:      true,
+		},
+		{
+			msg:        "rate limit without key",
+			extensions: `"x-rate-limit": {"requests": 100, "per": "1m"}`,
+			valid:      true,
+		},
+		{
+			msg:        "rate limit with fractional requests",
+			extensions: `"x-rate-limit": {"requests": 1.5, "per": "1m"}`,
+		},
+		{
+			msg:        "rate limit without per",
+			extensions: `"x-rate-limit": {"requests": 100}`,
+		},
+		{
+			msg:        "rate limit with unknown key",
+			extensions: `"x-rate-limit": {"requests": 100, "per": "1m", "key": "header"}`,
+		},
+		{
+			msg:        "rate limit with unknown field",
+			extensions: `"x-rate-limit": {"requests": 100, "per": "1m", "burst": 10}`,
+		},
+		{
+			msg:        "rate limit not an object",
+			extensions: `"x-rate-limit": 100`,
+		},
+		{
+			msg:        "valid idempotent",
+			extensions: `"x-idempotent": true`,
+			valid:      true,
+		},
+		{
+			msg:        "idempotent not a bool",
+			extensions: `"x-idempotent": "yes"`,
+		},
+		{
+			msg:        "valid if match",
+			extensions: `"x-if-match": "required"`,
+			valid:      true,
+		},
+		{
+			msg:        "invalid if match",
+			extensions: `"x-if-match": true`,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			spec := `{
+  "swagger": "2.0",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "operationId": "listPets",
+        ` + tc.extensions + `,
+        "responses": {"200": {"description": "The pets."}}
+      }
+    }
+  }
+}`
+			path := filepath.Join(t.TempDir(), "swagger.json")
+			err := os.WriteFile(path, []byte(spec), 0o644)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateExtensions(path)
+			if tc.valid && err != nil {
+				t.Errorf("should not fail: %s", err)
+			}
+			if !tc.valid && err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// writeChanged copies the files of the src dir to the dst dir, only writing
+// the files whose content differs, such that the modification times of the
+// unchanged files are kept. It returns the paths, relative to dst, of the
+// written files.
+func writeChanged(src, dst string) ([]string, error) {
+	var changed []string
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dst, rel)
+		current, err := os.ReadFile(target)
+		if err == nil && bytes.Equal(current, data) {
+			return nil
+		}
+
+		err = os.MkdirAll(filepath.Dir(target), 0o755)
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(target, data, 0o644)
+		if err != nil {
+			return err
+		}
+
+		changed = append(changed, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changed, nil
+}
+
+// generatedHeader is the prefix of the header of generated files.
+const generatedHeader = "// Code generated "
+
+// diffDirs returns a unified diff of the files of the dst dir against the
+// files generated in the src dir. If stale is set, generated files in the
+// dirs of dst which aren't in src are included as removed.
+func diffDirs(src, dst string, stale bool) (string, error) {
+	var diff strings.Builder
+	generated := make(map[string]bool)
+	dirs := make(map[string]bool)
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		generated[rel] = true
+		dirs[filepath.Dir(rel)] = true
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		current, err := os.ReadFile(filepath.Join(dst, rel))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		return writeDiff(&diff, dst, rel, string(current), string(data))
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if stale {
+		for _, dir := range sortedKeys(dirs) {
+			entries, err := os.ReadDir(filepath.Join(dst, dir))
+			if err != nil && !os.IsNotExist(err) {
+				return "", err
+			}
+
+			for _, entry := range entries {
+				rel := filepath.Join(dir, entry.Name())
+				if entry.IsDir() || generated[rel] {
+					continue
+				}
+
+				current, err := os.ReadFile(filepath.Join(dst, rel))
+				if err != nil {
+					return "", err
+				}
+
+				if strings.HasPrefix(string(current), generatedHeader) {
+					err = writeDiff(&diff, dst, rel, string(current), "")
+					if err != nil {
+						return "", err
+					}
+				}
+			}
+		}
+	}
+
+	return diff.String(), nil
+}
+
+// writeDiff writes the unified diff of the file at rel in dir from current
+// to generated to w.
+func writeDiff(w io.Writer, dir, rel, current, generated string) error {
+	if current == generated {
+		return nil
+	}
+
+	name := filepath.ToSlash(filepath.Join(dir, rel))
+	return difflib.WriteUnifiedDiff(w, difflib.UnifiedDiff{
+		A:        splitLines(current),
+		B:        splitLines(generated),
+		FromFile: name,
+		ToFile:   name,
+		Context:  3,
+	})
+}
+
+// splitLines splits s into lines for diffing. An empty s has no lines
+// rather than a single empty line.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return difflib.SplitLines(strings.TrimSuffix(s, "\n"))
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
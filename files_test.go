@@ -0,0 +1,131 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWriteChanged(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+
+	for name, content := range map[string]string{
+		"restapi/api.go":                "package restapi\n",
+		"restapi/operations/a/a_get.go": "package a\n",
+		"models/new.go":                 "package models\n",
+	} {
+		writeFile(t, filepath.Join(src, name), content)
+	}
+
+	writeFile(t, filepath.Join(dst, "restapi/api.go"), "package restapi\n")
+	writeFile(t, filepath.Join(dst, "restapi/operations/a/a_get.go"), "package old\n")
+
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	err := os.Chtimes(filepath.Join(dst, "restapi/api.go"), old, old)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := writeChanged(src, dst)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	expected := []string{
+		filepath.FromSlash("models/new.go"),
+		filepath.FromSlash("restapi/operations/a/a_get.go"),
+	}
+	if !reflect.DeepEqual(changed, expected) {
+		t.Errorf("expected changed files %v, got %v", expected, changed)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dst, "restapi/operations/a/a_get.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "package a\n" {
+		t.Errorf("expected changed file to be rewritten, got %q", data)
+	}
+
+	info, err := os.Stat(filepath.Join(dst, "restapi/api.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.ModTime().Equal(old) {
+		t.Errorf("expected unchanged file to keep modification time %s, got %s", old, info.ModTime())
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(path, []byte(content), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiffDirs(t *testing.T) {
+	dst := t.TempDir()
+
+	writeFile(t, filepath.Join(dst, "restapi/api.go"), "package restapi\n")
+	writeFile(t, filepath.Join(dst, "restapi/stale.go"), "// Code generated by gin-swagger; DO NOT EDIT.\n")
+	writeFile(t, filepath.Join(dst, "restapi/service.go"), "package restapi\n")
+
+	for _, tc := range []struct {
+		msg      string
+		files    map[string]string
+		stale    bool
+		expected string
+	}{
+		{
+			msg: "up to date",
+		},
+		{
+			msg:   "changed and new files",
+			files: map[string]string{"restapi/api.go": "package api\n", "models/pet.go": "package models\n"},
+			expected: "--- " + filepath.ToSlash(filepath.Join(dst, "models/pet.go")) + "\n" +
+				"+++ " + filepath.ToSlash(filepath.Join(dst, "models/pet.go")) + "\n" +
+				"@@ -0,0 +1 @@\n" +
+				"+package models\n" +
+				"--- " + filepath.ToSlash(filepath.Join(dst, "restapi/api.go")) + "\n" +
+				"+++ " + filepath.ToSlash(filepath.Join(dst, "restapi/api.go")) + "\n" +
+				"@@ -1 +1 @@\n" +
+				"-package restapi\n" +
+				"+package api\n",
+		},
+		{
+			msg:   "stale generated files",
+			stale: true,
+			expected: "--- " + filepath.ToSlash(filepath.Join(dst, "restapi/stale.go")) + "\n" +
+				"+++ " + filepath.ToSlash(filepath.Join(dst, "restapi/stale.go")) + "\n" +
+				"@@ -1 +0,0 @@\n" +
+				"-// Code generated by gin-swagger; DO NOT EDIT.\n",
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			src := t.TempDir()
+			writeFile(t, filepath.Join(src, "restapi/api.go"), "package restapi\n")
+			for name, content := range tc.files {
+				writeFile(t, filepath.Join(src, name), content)
+			}
+
+			diff, err := diffDirs(src, dst, tc.stale)
+			if err != nil {
+				t.Fatalf("should not fail: %s", err)
+			}
+
+			if diff != tc.expected {
+				t.Errorf("expected diff:\n%s\ngot:\n%s", tc.expected, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-swagger/go-swagger/generator"
+	yaml "go.yaml.in/yaml/v3"
+)
+
+// layoutConfig is the config file registering extra sections of generated
+// files. It uses the keys of the layout config of go-swagger.
+type layoutConfig struct {
+	Layout struct {
+		Application []sectionConfig `yaml:"application"`
+		Operations  []sectionConfig `yaml:"operations"`
+		Models      []sectionConfig `yaml:"models"`
+	} `yaml:"layout"`
+}
+
+// sectionConfig is a file generated from a template, for the application,
+// each operation or each model.
+type sectionConfig struct {
+	Name       string `yaml:"name"`
+	Source     string `yaml:"source"`
+	Target     string `yaml:"target"`
+	FileName   string `yaml:"file_name"`
+	SkipExists bool   `yaml:"skip_exists"`
+	SkipFormat bool   `yaml:"skip_format"`
+}
+
+// readLayout reads the layout config from the file at path. An empty path
+// gives an empty layout.
+func readLayout(path string) (*layoutConfig, error) {
+	layout := &layoutConfig{}
+	if path == "" {
+		return layout, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err = decoder.Decode(layout)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return layout, nil
+}
+
+// apply adds the sections of the layout to sections. A section with the
+// name of an existing section replaces it.
+func (l *layoutConfig) apply(sections *generator.SectionOpts) error {
+	var err error
+	sections.Application, err = mergeSections(sections.Application, l.Layout.Application)
+	if err != nil {
+		return err
+	}
+
+	sections.Operations, err = mergeSections(sections.Operations, l.Layout.Operations)
+	if err != nil {
+		return err
+	}
+
+	sections.Models, err = mergeSections(sections.Models, l.Layout.Models)
+	return err
+}
+
+// mergeSections adds the extra sections to sections, replacing the ones with
+// the same name.
+func mergeSections(sections []generator.TemplateOpts, extra []sectionConfig) ([]generator.TemplateOpts, error) {
+	for _, section := range extra {
+		switch {
+		case section.Name == "":
+			return nil, errors.New("invalid layout section: name is required")
+		case section.Source == "":
+			return nil, fmt.Errorf("invalid layout section '%s': source is required", section.Name)
+		case section.Target == "":
+			return nil, fmt.Errorf("invalid layout section '%s': target is required", section.Name)
+		case section.FileName == "":
+			return nil, fmt.Errorf("invalid layout section '%s': file_name is required", section.Name)
+		}
+
+		opts := generator.TemplateOpts{
+			Name:       section.Name,
+			Source:     section.Source,
+			Target:     section.Target,
+			FileName:   section.FileName,
+			SkipExists: section.SkipExists,
+			SkipFormat: section.SkipFormat,
+		}
+
+		replaced := false
+		for i := range sections {
+			if sections[i].Name == section.Name {
+				sections[i] = opts
+				replaced = true
+			}
+		}
+
+		if !replaced {
+			sections = append(sections, opts)
+		}
+	}
+
+	return sections, nil
+}
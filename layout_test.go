@@ -0,0 +1,127 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/go-swagger/go-swagger/generator"
+)
+
+func TestReadLayout(t *testing.T) {
+	for _, tc := range []struct {
+		msg      string
+		config   string
+		expected []sectionConfig
+		err      bool
+	}{
+		{
+			msg: "operation sections",
+			config: `
+layout:
+  operations:
+    - name: handler
+      source: templates/handler.gotmpl
+      target: "{{ .Target }}"
+      file_name: "{{ .Name }}_handler.go"
+      skip_exists: true
+`,
+			expected: []sectionConfig{
+				{
+					Name:       "handler",
+					Source:     "templates/handler.gotmpl",
+					Target:     "{{ .Target }}",
+					FileName:   "{{ .Name }}_handler.go",
+					SkipExists: true,
+				},
+			},
+		},
+		{
+			msg: "empty config",
+		},
+		{
+			msg:    "unknown field",
+			config: "layout:\n  operations:\n    - nme: handler\n",
+			err:    true,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			err := os.WriteFile(path, []byte(tc.config), 0o644)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			layout, err := readLayout(path)
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("should not fail: %s", err)
+			}
+
+			if !reflect.DeepEqual(layout.Layout.Operations, tc.expected) {
+				t.Errorf("expected operation sections %v, got %v", tc.expected, layout.Layout.Operations)
+			}
+		})
+	}
+}
+
+func TestLayoutApply(t *testing.T) {
+	server := generator.TemplateOpts{Name: "server", Source: "templates/api.gotmpl", Target: "restapi", FileName: "api.go"}
+	custom := sectionConfig{Name: "server", Source: "templates/custom.gotmpl", Target: "restapi", FileName: "api.go"}
+	stub := sectionConfig{Name: "stub", Source: "templates/stub.gotmpl", Target: "restapi", FileName: "stub.go", SkipExists: true}
+
+	for _, tc := range []struct {
+		msg      string
+		sections []sectionConfig
+		expected []generator.TemplateOpts
+		err      bool
+	}{
+		{
+			msg:      "extra section",
+			sections: []sectionConfig{stub},
+			expected: []generator.TemplateOpts{
+				server,
+				{Name: "stub", Source: "templates/stub.gotmpl", Target: "restapi", FileName: "stub.go", SkipExists: true},
+			},
+		},
+		{
+			msg:      "replaced section",
+			sections: []sectionConfig{custom},
+			expected: []generator.TemplateOpts{
+				{Name: "server", Source: "templates/custom.gotmpl", Target: "restapi", FileName: "api.go"},
+			},
+		},
+		{
+			msg:      "missing source",
+			sections: []sectionConfig{{Name: "stub", Target: "restapi", FileName: "stub.go"}},
+			err:      true,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			layout := &layoutConfig{}
+			layout.Layout.Application = tc.sections
+
+			sections := &generator.SectionOpts{Application: []generator.TemplateOpts{server}}
+			err := layout.apply(sections)
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("should not fail: %s", err)
+			}
+
+			if !reflect.DeepEqual(sections.Application, tc.expected) {
+				t.Errorf("expected application sections %v, got %v", tc.expected, sections.Application)
+			}
+		})
+	}
+}
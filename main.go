@@ -2,9 +2,14 @@ package main
 
 import (
 	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
 	"log"
 	"os"
 	"path"
+	"path/filepath"
+	"strings"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/go-openapi/analysis"
@@ -18,30 +23,131 @@ var templates embed.FS
 
 const (
 	defaultSwaggerPath = "./swagger.json"
+	// defaultPrincipal is the principal passed to context-aware Service
+	// methods if no other type is specified.
+	defaultPrincipal = "github.com/mikkeloscar/gin-swagger/middleware.Principal"
 )
 
+// generateConfig is the configuration of the generate command.
+type generateConfig struct {
+	Application    string
+	SwaggerPath    string
+	Target         string
+	ServerPackage  string
+	APIPackage     string
+	ModelPackage   string
+	ClientPackage  string
+	Tags           []string
+	Operations     []string
+	Models         []string
+	ExistingModels string
+	CopyrightFile  string
+	OnlyChanged    bool
+	Check          bool
+	TemplateDir    string
+	StubType       string
+	StubDir        string
+	ConfigFile     string
+	Client         bool
+	TestHarness    bool
+	Context        bool
+	Principal      string
+}
+
+// errOutdated is returned by run in check mode if the generated code
+// differs from the code in the target.
+var errOutdated = errors.New("the generated code is out of date, rerun gin-swagger")
+
 var (
-	config struct {
-		Application string
+	config     generateConfig
+	mockConfig struct {
 		SwaggerPath string
+		Address     string
 	}
 )
 
 func main() {
-	kingpin.Flag("application", "Name of the application (passed directly to swagger).").
+	generate := kingpin.Command("generate", "Generate a gin server from the spec.").Default()
+	generate.Flag("application", "Name of the application (passed directly to swagger).").
 		Required().Short('A').StringVar(&config.Application)
-	kingpin.Flag("spec", "the spec file to use.").
+	generate.Flag("spec", "the spec file to use.").
 		Short('f').Default(defaultSwaggerPath).StringVar(&config.SwaggerPath)
-	kingpin.Parse()
+	generate.Flag("target", "The directory to generate the code in.").
+		Short('t').Default("./").StringVar(&config.Target)
+	generate.Flag("server-package", "The package name of the generated server.").
+		Default("restapi").StringVar(&config.ServerPackage)
+	generate.Flag("api-package", "The package name of the generated operations, relative to the server package.").
+		Default("operations").StringVar(&config.APIPackage)
+	generate.Flag("model-package", "The package name of the generated models.").
+		Default("models").StringVar(&config.ModelPackage)
+	generate.Flag("client-package", "The package name of the generated client.").
+		Default("client").StringVar(&config.ClientPackage)
+	generate.Flag("tag", "Only generate the operations with the tag. Can be repeated.").
+		StringsVar(&config.Tags)
+	generate.Flag("operation", "Only generate the operation with the operation id. Can be repeated.").
+		Short('O').StringsVar(&config.Operations)
+	generate.Flag("model", "Only generate the model with the name. Can be repeated.").
+		Short('M').StringsVar(&config.Models)
+	generate.Flag("existing-models", "Import path of existing models, e.g. github.com/org/repo/models. No models are generated.").
+		StringVar(&config.ExistingModels)
+	generate.Flag("copyright-file", "File with a copyright header added to the generated files.").
+		Short('r').StringVar(&config.CopyrightFile)
+	generate.Flag("only-changed", "Only write the generated files whose content changed.").
+		BoolVar(&config.OnlyChanged)
+	generate.Flag("check", "Check that the generated code is up to date without writing it. Prints a diff and fails if it isn't.").
+		BoolVar(&config.Check)
+	generate.Flag("template-dir", "Directory with templates overriding the embedded templates of the same name.").
+		Short('T').StringVar(&config.TemplateDir)
+	generate.Flag("config-file", "YAML file registering extra sections of generated files.").
+		Short('C').StringVar(&config.ConfigFile)
+	generate.Flag("stub-type", "Type implementing the Service to add stubs of its missing methods for, in service_stub.go of --stub-dir.").
+		StringVar(&config.StubType)
+	generate.Flag("stub-dir", "Directory of the package of the --stub-type.").
+		Default(".").StringVar(&config.StubDir)
+	generate.Flag("client", "Also generate a client package for the API.").
+		BoolVar(&config.Client)
+	generate.Flag("test-harness", "Also generate a restapitest package with a fake Service and a test server.").
+		BoolVar(&config.TestHarness)
+	generate.Flag("context", "Generate Service methods taking a context.Context and the authenticated principal instead of the *gin.Context.").
+		BoolVar(&config.Context)
+	generate.Flag("principal", "Type of the principal passed to the Service methods, e.g. github.com/org/repo/auth.User. Implies --context.").
+		StringVar(&config.Principal)
+
+	mock := kingpin.Command("mock", "Serve mock responses for the operations of the spec.")
+	mock.Flag("spec", "the spec file to use.").
+		Short('f').Default(defaultSwaggerPath).StringVar(&mockConfig.SwaggerPath)
+	mock.Flag("address", "Address to listen on, e.g. :8080 or 0.0.0.0:8080.").
+		Default(defaultMockAddress).StringVar(&mockConfig.Address)
+
+	switch kingpin.Parse() {
+	case mock.FullCommand():
+		err := runMock(mockConfig.SwaggerPath, mockConfig.Address)
+		if err != nil {
+			log.Fatalf("failed to run mock server: %s", err)
+		}
+		return
+	}
+
+	if config.Context && config.Principal == "" {
+		config.Principal = defaultPrincipal
+	}
 
-	err := run(config.Application, config.SwaggerPath)
+	err := run(config)
+	if errors.Is(err, errOutdated) {
+		log.Fatal(err)
+	}
 	if err != nil {
 		log.Fatalf("failed to run swagger: %s", err)
 	}
 }
 
-func run(application, specPath string) error {
-	templatesDir, err := extractTemplatesDir()
+func run(cfg generateConfig) error {
+	layout, err := readLayout(cfg.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	templatesDir, err := extractTemplatesDir(cfg.TemplateDir)
 	if err != nil {
 		return err
 	}
@@ -49,16 +155,59 @@ func run(application, specPath string) error {
 		_ = os.RemoveAll(templatesDir)
 	}()
 
+	spec, err := loadSpec(cfg.SwaggerPath, true)
+	if err != nil {
+		return err
+	}
+	defer spec.Cleanup()
+
+	err = validateExtensions(spec.Path)
+	if err != nil {
+		return err
+	}
+
+	copyright, err := readCopyright(cfg.CopyrightFile)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(cfg.Target, 0o755)
+	if err != nil {
+		return err
+	}
+
+	// when only writing the changed files or checking the generated code,
+	// the code is generated into a scratch dir inside the target, such
+	// that it's part of the same module, and compared afterwards.
+	scratch := cfg.OnlyChanged || cfg.Check
+	target := cfg.Target
+	if scratch {
+		target, err = os.MkdirTemp(cfg.Target, ".gin-swagger-*")
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = os.RemoveAll(target)
+		}()
+	}
+
+	// go-swagger imports existing models as models, so the generated code
+	// must refer to them by that name.
+	modelPackage := cfg.ModelPackage
+	if cfg.ExistingModels != "" {
+		modelPackage = "models"
+	}
+
 	opts := &generator.GenOpts{
-		Spec:              specPath,
-		Target:            "./",
-		APIPackage:        "operations",
-		ModelPackage:      "models",
-		ServerPackage:     "restapi",
-		ClientPackage:     "client",
-		Principal:         "",
+		Spec:              spec.Path,
+		Target:            target,
+		APIPackage:        cfg.APIPackage,
+		ModelPackage:      modelPackage,
+		ServerPackage:     cfg.ServerPackage,
+		ClientPackage:     cfg.ClientPackage,
+		Principal:         cfg.Principal,
 		DefaultScheme:     "http",
-		IncludeModel:      true,
+		IncludeModel:      cfg.ExistingModels == "",
 		IncludeValidator:  true,
 		IncludeHandler:    true,
 		IncludeParameters: true,
@@ -76,14 +225,14 @@ func run(application, specPath string) error {
 		ExcludeSpec:       false,
 		TemplateDir:       templatesDir,
 		DumpData:          false,
-		Models:            nil,
-		Operations:        nil,
-		Tags:              nil,
-		Name:              application,
+		Models:            cfg.Models,
+		Operations:        cfg.Operations,
+		Tags:              cfg.Tags,
+		Name:              cfg.Application,
 		FlagStrategy:      "go-flags",
 		CompatibilityMode: "modern",
-		ExistingModels:    "",
-		Copyright:         "",
+		ExistingModels:    cfg.ExistingModels,
+		Copyright:         copyright,
 		Sections: generator.SectionOpts{
 			Application: []generator.TemplateOpts{
 				{
@@ -120,6 +269,14 @@ func run(application, specPath string) error {
 					SkipExists: false,
 					SkipFormat: false,
 				},
+				{
+					Name:       "responses",
+					Source:     "templates/responses.gotmpl",
+					Target:     "{{ if gt (len .Tags) 0 }}{{ joinFilePath .Target .ServerPackage .APIPackage .Package  }}{{ else }}{{ joinFilePath .Target .ServerPackage .Package  }}{{ end }}",
+					FileName:   "{{ (snakize (pascalize .Name)) }}_responses.go",
+					SkipExists: false,
+					SkipFormat: false,
+				},
 			},
 			Models: []generator.TemplateOpts{
 				{
@@ -134,53 +291,179 @@ func run(application, specPath string) error {
 		},
 	}
 
+	if cfg.Client {
+		opts.Sections.Application = append(opts.Sections.Application, generator.TemplateOpts{
+			Name:       "client",
+			Source:     "templates/client.gotmpl",
+			Target:     "{{ joinFilePath .Target .ClientPackage }}",
+			FileName:   "client.go",
+			SkipExists: false,
+			SkipFormat: false,
+		})
+	}
+
+	if cfg.TestHarness {
+		opts.Sections.Application = append(opts.Sections.Application, generator.TemplateOpts{
+			Name:       "restapitest",
+			Source:     "templates/restapitest.gotmpl",
+			Target:     "{{ joinFilePath .Target (printf \"%stest\" .ServerPackage) }}",
+			FileName:   "restapitest.go",
+			SkipExists: false,
+			SkipFormat: false,
+		})
+	}
+
+	// the request builders are used by both the client and the test
+	// harness.
+	if cfg.Client || cfg.TestHarness {
+		opts.Sections.Operations = append(opts.Sections.Operations, generator.TemplateOpts{
+			Name:       "request",
+			Source:     "templates/request.gotmpl",
+			Target:     "{{ if gt (len .Tags) 0 }}{{ joinFilePath .Target .ServerPackage .APIPackage .Package  }}{{ else }}{{ joinFilePath .Target .ServerPackage .Package  }}{{ end }}",
+			FileName:   "{{ (snakize (pascalize .Name)) }}_request.go",
+			SkipExists: false,
+			SkipFormat: false,
+		})
+	}
+
+	err = layout.apply(&opts.Sections)
+	if err != nil {
+		return err
+	}
+
 	err = opts.Prepare()
 	if err != nil {
 		return err
 	}
 
-	err = generator.GenerateServer(application, nil, nil, opts)
+	if scratch {
+		// resolve the import paths as if generating into the target
+		// rather than the scratch dir.
+		baseImport := opts.LanguageOpts.BaseImportFunc
+		opts.LanguageOpts.BaseImportFunc = func(dir string) string {
+			rel, err := filepath.Rel(target, dir)
+			if err != nil {
+				return baseImport(dir)
+			}
+			return baseImport(filepath.Join(cfg.Target, rel))
+		}
+	}
+
+	err = generator.GenerateServer(cfg.Application, cfg.Models, cfg.Operations, opts)
 	if err != nil {
 		return err
 	}
 
+	if spec.IsOpenAPI3() {
+		err = writeOpenAPISpec(spec, opts.Target, opts.ServerPackage, copyright)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cfg.Check {
+		// stale files can only be detected if everything is generated.
+		stale := len(cfg.Tags) == 0 && len(cfg.Operations) == 0 && len(cfg.Models) == 0
+		diff, err := diffDirs(target, cfg.Target, stale)
+		if err != nil {
+			return err
+		}
+
+		if diff != "" {
+			fmt.Print(diff)
+			return errOutdated
+		}
+		return nil
+	}
+
+	if cfg.OnlyChanged {
+		changed, err := writeChanged(target, cfg.Target)
+		if err != nil {
+			return err
+		}
+		log.Printf("Wrote %d changed files", len(changed))
+	}
+
+	if cfg.StubType != "" {
+		apiFile := filepath.Join(cfg.Target, cfg.ServerPackage, "api.go")
+		err = writeServiceStubs(apiFile, cfg.StubDir, cfg.StubType)
+		if err != nil {
+			return fmt.Errorf("failed to write service stubs: %w", err)
+		}
+	}
+
 	return nil
 }
 
-func extractTemplatesDir() (string, error) {
+// readCopyright reads the copyright header from the file at path. An empty
+// path gives no header.
+func readCopyright(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read copyright file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\n"), nil
+}
+
+// extractTemplatesDir writes the embedded templates to a temp dir, with the
+// templates in overrideDir, if set, taking precedence over the embedded
+// templates of the same name.
+func extractTemplatesDir(overrideDir string) (string, error) {
 	tmpDir, err := os.MkdirTemp("", "gin-swagger-templates-*")
 	if err != nil {
 		return "", err
 	}
 
-	assets, err := templates.ReadDir("templates")
+	err = writeTemplates(tmpDir, templates, "templates")
 	if err != nil {
 		_ = os.RemoveAll(tmpDir)
 		return "", err
 	}
 
+	if overrideDir != "" {
+		err = writeTemplates(tmpDir, os.DirFS(overrideDir), ".")
+		if err != nil {
+			_ = os.RemoveAll(tmpDir)
+			return "", fmt.Errorf("failed to read template dir: %w", err)
+		}
+	}
+
+	return tmpDir, nil
+}
+
+// writeTemplates writes the files of the dir of fsys to the templates dir
+// of tmpDir.
+func writeTemplates(tmpDir string, fsys fs.FS, dir string) error {
+	assets, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+
 	for _, asset := range assets {
 		if asset.IsDir() {
 			continue
 		}
 
-		data, readErr := templates.ReadFile(path.Join("templates", asset.Name()))
-		if readErr != nil {
-			_ = os.RemoveAll(tmpDir)
-			return "", readErr
+		data, err := fs.ReadFile(fsys, path.Join(dir, asset.Name()))
+		if err != nil {
+			return err
 		}
 
 		templatePath := path.Join(tmpDir, "templates", asset.Name())
-		if mkdirErr := os.MkdirAll(path.Dir(templatePath), 0o755); mkdirErr != nil {
-			_ = os.RemoveAll(tmpDir)
-			return "", mkdirErr
+		err = os.MkdirAll(path.Dir(templatePath), 0o755)
+		if err != nil {
+			return err
 		}
 
-		if writeErr := os.WriteFile(templatePath, data, 0o644); writeErr != nil {
-			_ = os.RemoveAll(tmpDir)
-			return "", writeErr
+		err = os.WriteFile(templatePath, data, 0o644)
+		if err != nil {
+			return err
 		}
 	}
 
-	return tmpDir, nil
+	return nil
 }
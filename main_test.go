@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtractTemplatesDir(t *testing.T) {
+	overrideDir := t.TempDir()
+	writeFile(t, filepath.Join(overrideDir, "api.gotmpl"), "custom api")
+	writeFile(t, filepath.Join(overrideDir, "stub.gotmpl"), "custom stub")
+
+	templatesDir, err := extractTemplatesDir(overrideDir)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+	defer os.RemoveAll(templatesDir)
+
+	for name, expected := range map[string]string{
+		"api.gotmpl":  "custom api",
+		"stub.gotmpl": "custom stub",
+	} {
+		data, err := os.ReadFile(filepath.Join(templatesDir, "templates", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(data) != expected {
+			t.Errorf("expected template %s to be overridden with %q, got %q", name, expected, data)
+		}
+	}
+
+	embedded, err := templates.ReadFile("templates/config.gotmpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(templatesDir, "templates", "config.gotmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != string(embedded) {
+		t.Error("expected embedded template config.gotmpl to be kept")
+	}
+}
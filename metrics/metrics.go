@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics records Prometheus metrics for the requests of the operations.
+// Requests are labeled by the operation ID of the spec, the HTTP method
+// and the response status code.
+type Metrics struct {
+	requests     *prometheus.CounterVec
+	duration     *prometheus.HistogramVec
+	inFlight     *prometheus.GaugeVec
+	responseSize *prometheus.HistogramVec
+}
+
+// New creates Metrics and registers the collectors with the registerer.
+func New(registerer prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests by operation, method and status code.",
+		}, []string{"operation", "method", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Latency of HTTP requests by operation, method and status code.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"operation", "method", "code"}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "http_requests_in_flight",
+			Help: "Number of HTTP requests currently being served by operation.",
+		}, []string{"operation"}),
+		responseSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_response_size_bytes",
+			Help:    "Size of HTTP responses by operation, method and status code.",
+			Buckets: prometheus.ExponentialBuckets(100, 10, 6),
+		}, []string{"operation", "method", "code"}),
+	}
+
+	for _, collector := range []prometheus.Collector{m.requests, m.duration, m.inFlight, m.responseSize} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// NewRegistry creates a Prometheus registry with the Go runtime and process
+// collectors registered.
+func NewRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return registry
+}
+
+// Middleware is a middleware recording the metrics of the requests to the
+// operation.
+func (m *Metrics) Middleware(operationID string) gin.HandlerFunc {
+	inFlight := m.inFlight.WithLabelValues(operationID)
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		inFlight.Inc()
+		defer inFlight.Dec()
+
+		c.Next()
+
+		code := strconv.Itoa(c.Writer.Status())
+		method := c.Request.Method
+		m.requests.WithLabelValues(operationID, method, code).Inc()
+		m.duration.WithLabelValues(operationID, method, code).Observe(time.Since(start).Seconds())
+		m.responseSize.WithLabelValues(operationID, method, code).Observe(float64(max(c.Writer.Size(), 0)))
+	}
+}
+
+// Handler is a handler serving the metrics of the gatherer in the
+// Prometheus exposition format.
+func Handler(gatherer prometheus.Gatherer) gin.HandlerFunc {
+	handler := promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})
+	return func(c *gin.Context) {
+		handler.ServeHTTP(c.Writer, c.Request)
+	}
+}
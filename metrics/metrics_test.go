@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	registry := prometheus.NewRegistry()
+	m, err := New(registry)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	_, err = New(registry)
+	if err == nil {
+		t.Errorf("expected registering twice to fail")
+	}
+
+	router := gin.New()
+	router.GET("/metrics", Handler(registry))
+	router.GET("/clusters", m.Middleware("list_clusters"), func(c *gin.Context) {
+		c.String(http.StatusOK, "[]")
+	})
+	router.GET("/clusters/:id", m.Middleware("get_cluster"), func(c *gin.Context) {
+		c.Status(http.StatusNotFound)
+	})
+
+	for _, path := range []string{"/clusters", "/clusters", "/clusters/a"} {
+		req := httptest.NewRequest("GET", path, nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	for _, tc := range []struct {
+		operation string
+		code      string
+		expected  float64
+	}{
+		{operation: "list_clusters", code: "200", expected: 2},
+		{operation: "get_cluster", code: "404", expected: 1},
+	} {
+		t.Run(tc.operation, func(t *testing.T) {
+			count := testutil.ToFloat64(m.requests.WithLabelValues(tc.operation, "GET", tc.code))
+			if count != tc.expected {
+				t.Errorf("expected %v requests, got %v", tc.expected, count)
+			}
+
+			inFlight := testutil.ToFloat64(m.inFlight.WithLabelValues(tc.operation))
+			if inFlight != 0 {
+				t.Errorf("expected no requests in flight, got %v", inFlight)
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	if !strings.Contains(w.Body.String(), `http_request_duration_seconds_count{code="200",method="GET",operation="list_clusters"} 2`) {
+		t.Errorf("expected latency histogram to be served, got %s", w.Body.String())
+	}
+}
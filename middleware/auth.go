@@ -0,0 +1,204 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mikkeloscar/gin-swagger/api"
+)
+
+// APIKeyValidator validates the API key of a request and returns the user
+// it belongs to. An error denies access.
+type APIKeyValidator func(ctx context.Context, key string) (User, error)
+
+// BasicAuthValidator validates the username and password of a request
+// using HTTP basic authentication and returns the authenticated user. An
+// error denies access.
+type BasicAuthValidator func(ctx context.Context, username, password string) (User, error)
+
+// Authenticator authenticates a request for a single security scheme
+// without aborting it. It returns the authenticated principal or an
+// *AuthError describing why access is denied.
+type Authenticator func(c *gin.Context) (*Principal, error)
+
+// AuthError is returned by an Authenticator if a request is denied.
+type AuthError struct {
+	// Status is the response code, 401 or 403.
+	Status int
+	// Challenge is the WWW-Authenticate challenge of the response.
+	Challenge string
+	// Detail describes why the request is denied.
+	Detail string
+}
+
+// Error returns the detail of the error.
+func (e *AuthError) Error() string {
+	return e.Detail
+}
+
+// unauthorized returns a 401 AuthError with the challenge.
+func unauthorized(challenge, detail string) *AuthError {
+	return &AuthError{
+		Status:    http.StatusUnauthorized,
+		Challenge: challenge,
+		Detail:    detail,
+	}
+}
+
+// APIKeyAuthenticator authenticates requests by the API key in the header
+// or query parameter name, depending on in. The key is denied if it's
+// missing, no validator is configured or the validator rejects the key.
+func APIKeyAuthenticator(name, in string, validator APIKeyValidator) Authenticator {
+	challenge := fmt.Sprintf(`APIKey in="%s", name="%s"`, in, name)
+
+	return func(c *gin.Context) (*Principal, error) {
+		var key string
+		switch in {
+		case "query":
+			key = c.Query(name)
+		default:
+			key = c.GetHeader(name)
+		}
+
+		if key == "" {
+			return nil, unauthorized(challenge, fmt.Sprintf("missing API key '%s' in %s", name, in))
+		}
+
+		if validator == nil {
+			return nil, unauthorized(challenge, "no validator configured for API key")
+		}
+
+		user, err := validator(c.Request.Context(), key)
+		if err != nil {
+			return nil, unauthorized(challenge, err.Error())
+		}
+
+		return &Principal{User: user}, nil
+	}
+}
+
+// BearerAuthenticator authenticates requests by the bearer token of the
+// Authorization header, validated as an API key by the validator. It's used
+// for OpenAPI 3 http bearer security schemes not validated as JWTs.
+func BearerAuthenticator(validator APIKeyValidator) Authenticator {
+	return func(c *gin.Context) (*Principal, error) {
+		token, ok := bearerToken(c.Request)
+		if !ok {
+			return nil, unauthorized("Bearer", "missing bearer token")
+		}
+
+		if validator == nil {
+			return nil, unauthorized("Bearer", "no validator configured for bearer token")
+		}
+
+		user, err := validator(c.Request.Context(), token)
+		if err != nil {
+			return nil, unauthorized(`Bearer error="invalid_token"`, err.Error())
+		}
+
+		return &Principal{User: user}, nil
+	}
+}
+
+// BasicAuthenticator authenticates requests using HTTP basic
+// authentication. The challenge of a denied request includes the realm.
+func BasicAuthenticator(realm string, validator BasicAuthValidator) Authenticator {
+	challenge := fmt.Sprintf(`Basic realm="%s", charset="UTF-8"`, realm)
+
+	return func(c *gin.Context) (*Principal, error) {
+		username, password, ok := c.Request.BasicAuth()
+		if !ok {
+			return nil, unauthorized(challenge, "missing basic auth credentials")
+		}
+
+		if validator == nil {
+			return nil, unauthorized(challenge, "no validator configured for basic auth")
+		}
+
+		user, err := validator(c.Request.Context(), username, password)
+		if err != nil {
+			return nil, unauthorized(challenge, err.Error())
+		}
+
+		return &Principal{User: user}, nil
+	}
+}
+
+// APIKeyAuth is a middleware that authenticates requests by the API key in
+// the header or query parameter name, depending on in. It responds with 401
+// if the key is missing, no validator is configured or the validator
+// rejects the key. The user returned by the validator is set on the
+// gin.Context, see GetUser.
+func APIKeyAuth(name, in string, validator APIKeyValidator) gin.HandlerFunc {
+	return authenticate(APIKeyAuthenticator(name, in, validator))
+}
+
+// BearerAuth is a middleware that authenticates requests by the bearer
+// token of the Authorization header, validated as an API key by the
+// validator. It's used for OpenAPI 3 http bearer security schemes not
+// validated as JWTs. It responds with 401 if the token is missing, no
+// validator is configured or the validator rejects the token.
+func BearerAuth(validator APIKeyValidator) gin.HandlerFunc {
+	return authenticate(BearerAuthenticator(validator))
+}
+
+// BasicAuth is a middleware that authenticates requests using HTTP basic
+// authentication. It responds with 401 and a challenge for the realm if the
+// credentials are missing, no validator is configured or the validator
+// rejects the credentials. The user returned by the validator is set on
+// the gin.Context, see GetUser.
+func BasicAuth(realm string, validator BasicAuthValidator) gin.HandlerFunc {
+	return authenticate(BasicAuthenticator(realm, validator))
+}
+
+// authenticate turns the Authenticator into a middleware which aborts
+// denied requests.
+func authenticate(authenticator Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, err := authenticator(c)
+		if err != nil {
+			abortAuthError(c, toAuthError(err))
+			return
+		}
+
+		setPrincipal(c, principal)
+		c.Next()
+	}
+}
+
+// setUser sets the uid and realm of the user on the gin.Context.
+func setUser(c *gin.Context, user User) {
+	if user.UID != "" {
+		c.Set("uid", user.UID)
+	}
+	if user.Realm != "" {
+		c.Set("realm", user.Realm)
+	}
+}
+
+// abortAuthError aborts the request with a Problem response for the
+// AuthError.
+func abortAuthError(c *gin.Context, err *AuthError) {
+	title := "Unauthorized."
+	if err.Status == http.StatusForbidden {
+		title = "Forbidden."
+	}
+
+	if err.Challenge != "" {
+		c.Writer.Header().Set("WWW-Authenticate", err.Challenge)
+	}
+
+	abortProblem(c, api.Problem{
+		Title:  title,
+		Status: err.Status,
+		Detail: err.Detail,
+	})
+}
+
+// abortProblem aborts the request with the Problem response.
+func abortProblem(c *gin.Context, problem api.Problem) {
+	c.Writer.Header().Set("Content-Type", problemMediaType)
+	c.AbortWithStatusJSON(problem.Status, problem)
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func validateKey(_ context.Context, key string) (User, error) {
+	if key != "secret" {
+		return User{}, errors.New("invalid key")
+	}
+	return User{UID: "johndoe", Realm: "/services"}, nil
+}
+
+func validateCredentials(_ context.Context, username, password string) (User, error) {
+	if username != "johndoe" || password != "secret" {
+		return User{}, errors.New("invalid credentials")
+	}
+	return User{UID: username}, nil
+}
+
+func TestAuthMiddlewares(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	for _, tc := range []struct {
+		msg        string
+		auth       gin.HandlerFunc
+		setup      func(req *http.Request)
+		statusCode int
+		challenge  string
+	}{
+		{
+			msg:        "valid API key in header",
+			auth:       APIKeyAuth("X-API-Key", "header", validateKey),
+			setup:      func(req *http.Request) { req.Header.Set("X-API-Key", "secret") },
+			statusCode: http.StatusOK,
+		},
+		{
+			msg:  "valid API key in query",
+			auth: APIKeyAuth("api_key", "query", validateKey),
+			setup: func(req *http.Request) {
+				req.URL.RawQuery = "api_key=secret"
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			msg:        "missing API key",
+			auth:       APIKeyAuth("X-API-Key", "header", validateKey),
+			statusCode: http.StatusUnauthorized,
+			challenge:  `APIKey in="header", name="X-API-Key"`,
+		},
+		{
+			msg:        "invalid API key",
+			auth:       APIKeyAuth("X-API-Key", "header", validateKey),
+			setup:      func(req *http.Request) { req.Header.Set("X-API-Key", "invalid") },
+			statusCode: http.StatusUnauthorized,
+			challenge:  `APIKey in="header", name="X-API-Key"`,
+		},
+		{
+			msg:        "no API key validator configured",
+			auth:       APIKeyAuth("X-API-Key", "header", nil),
+			setup:      func(req *http.Request) { req.Header.Set("X-API-Key", "secret") },
+			statusCode: http.StatusUnauthorized,
+			challenge:  `APIKey in="header", name="X-API-Key"`,
+		},
+		{
+			msg:        "valid basic auth",
+			auth:       BasicAuth("Example", validateCredentials),
+			setup:      func(req *http.Request) { req.SetBasicAuth("johndoe", "secret") },
+			statusCode: http.StatusOK,
+		},
+		{
+			msg:        "invalid basic auth",
+			auth:       BasicAuth("Example", validateCredentials),
+			setup:      func(req *http.Request) { req.SetBasicAuth("johndoe", "invalid") },
+			statusCode: http.StatusUnauthorized,
+			challenge:  `Basic realm="Example", charset="UTF-8"`,
+		},
+		{
+			msg:        "missing basic auth",
+			auth:       BasicAuth("Example", validateCredentials),
+			statusCode: http.StatusUnauthorized,
+			challenge:  `Basic realm="Example", charset="UTF-8"`,
+		},
+		{
+			msg:        "valid bearer token",
+			auth:       BearerAuth(validateKey),
+			setup:      func(req *http.Request) { req.Header.Set("Authorization", "Bearer secret") },
+			statusCode: http.StatusOK,
+		},
+		{
+			msg:        "invalid bearer token",
+			auth:       BearerAuth(validateKey),
+			setup:      func(req *http.Request) { req.Header.Set("Authorization", "Bearer invalid") },
+			statusCode: http.StatusUnauthorized,
+			challenge:  `Bearer error="invalid_token"`,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/", tc.auth, func(c *gin.Context) {
+				if GetUser(c).UID != "johndoe" {
+					t.Errorf("expected user to be set, got %#v", GetUser(c))
+				}
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest("GET", "/", nil)
+			if tc.setup != nil {
+				tc.setup(req)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("expected response code %d, got %d", tc.statusCode, w.Code)
+			}
+
+			if challenge := w.Header().Get("WWW-Authenticate"); challenge != tc.challenge {
+				t.Errorf("expected challenge '%s', got '%s'", tc.challenge, challenge)
+			}
+		})
+	}
+}
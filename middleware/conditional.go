@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mikkeloscar/gin-swagger/api"
+)
+
+const computeETagsContextKey = "compute_etags"
+
+// ComputeETags is a middleware that makes WriteResponse compute a strong
+// entity tag from the encoded body of successful responses without an
+// ETag, such that requests with a matching If-None-Match are responded
+// with 304.
+func ComputeETags() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(computeETagsContextKey, true)
+		c.Next()
+	}
+}
+
+// computeETag returns the strong entity tag of the encoded body.
+func computeETag(body []byte) string {
+	return fmt.Sprintf(`"%x"`, sha256.Sum256(body))
+}
+
+// setValidators sets the ETag and Last-Modified headers of the response.
+func setValidators(c *gin.Context, etag string, lastModified time.Time) {
+	if etag != "" {
+		c.Header("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+}
+
+// notModified returns true if the GET or HEAD request has an If-None-Match
+// matching the entity tag, or else an If-Modified-Since not before the last
+// modification.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		return etag != "" && matchETag(ifNoneMatch, etag, false)
+	}
+
+	if lastModified.IsZero() {
+		return false
+	}
+
+	ifModifiedSince, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(ifModifiedSince)
+}
+
+// matchETag returns true if any entity tag of the If-Match or
+// If-None-Match header matches the entity tag. Weak entity tags never
+// match with the strong comparison used for If-Match.
+func matchETag(header, etag string, strong bool) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+
+	if strong && strings.HasPrefix(etag, "W/") {
+		return false
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if strong && strings.HasPrefix(tag, "W/") {
+			continue
+		}
+
+		if strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// PreconditionProblem evaluates the If-Match header of the request against
+// the current entity tag of the resource, which is empty if it doesn't
+// exist. It returns a 412 Problem if the header doesn't match, and a 428
+// Problem if the header is required but missing. Otherwise nil is returned
+// and the request may proceed.
+func PreconditionProblem(c *gin.Context, etag string, required bool) *api.Problem {
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		if !required {
+			return nil
+		}
+
+		return &api.Problem{
+			Title:  "Precondition Required.",
+			Status: http.StatusPreconditionRequired,
+			Detail: "the request must be conditional with an If-Match header",
+		}
+	}
+
+	if etag == "" || !matchETag(ifMatch, etag, true) {
+		return &api.Problem{
+			Title:  "Precondition Failed.",
+			Status: http.StatusPreconditionFailed,
+			Detail: "the If-Match header doesn't match the current entity tag of the resource",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mikkeloscar/gin-swagger/api"
+)
+
+func TestWriteResponseConditional(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	lastModified := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	computed := computeETag([]byte("{\"name\":\"rex\"}\n"))
+
+	for _, tc := range []struct {
+		msg          string
+		method       string
+		computeETags bool
+		response     *api.Response
+		header       http.Header
+		statusCode   int
+		etag         string
+	}{
+		{
+			msg:        "matching If-None-Match",
+			method:     http.MethodGet,
+			response:   &api.Response{Code: http.StatusOK, Body: map[string]string{"name": "rex"}, ETag: `"v1"`},
+			header:     http.Header{"If-None-Match": []string{`"v0", W/"v1"`}},
+			statusCode: http.StatusNotModified,
+			etag:       `"v1"`,
+		},
+		{
+			msg:        "mismatching If-None-Match",
+			method:     http.MethodGet,
+			response:   &api.Response{Code: http.StatusOK, Body: map[string]string{"name": "rex"}, ETag: `"v1"`},
+			header:     http.Header{"If-None-Match": []string{`"v0"`}},
+			statusCode: http.StatusOK,
+			etag:       `"v1"`,
+		},
+		{
+			msg:          "computed entity tag",
+			method:       http.MethodGet,
+			computeETags: true,
+			response:     &api.Response{Code: http.StatusOK, Body: map[string]string{"name": "rex"}},
+			header:       http.Header{"If-None-Match": []string{computed}},
+			statusCode:   http.StatusNotModified,
+			etag:         computed,
+		},
+		{
+			msg:        "no computed entity tag",
+			method:     http.MethodGet,
+			response:   &api.Response{Code: http.StatusOK, Body: map[string]string{"name": "rex"}},
+			header:     http.Header{"If-None-Match": []string{computed}},
+			statusCode: http.StatusOK,
+		},
+		{
+			msg:        "not modified since",
+			method:     http.MethodGet,
+			response:   &api.Response{Code: http.StatusOK, Body: map[string]string{"name": "rex"}, LastModified: lastModified},
+			header:     http.Header{"If-Modified-Since": []string{lastModified.Format(http.TimeFormat)}},
+			statusCode: http.StatusNotModified,
+		},
+		{
+			msg:        "modified since",
+			method:     http.MethodGet,
+			response:   &api.Response{Code: http.StatusOK, Body: map[string]string{"name": "rex"}, LastModified: lastModified},
+			header:     http.Header{"If-Modified-Since": []string{lastModified.Add(-time.Hour).Format(http.TimeFormat)}},
+			statusCode: http.StatusOK,
+		},
+		{
+			msg:        "error response",
+			method:     http.MethodGet,
+			response:   &api.Response{Code: http.StatusNotFound, Body: api.Problem{Status: http.StatusNotFound}, ETag: `"v1"`},
+			header:     http.Header{"If-None-Match": []string{`"v1"`}},
+			statusCode: http.StatusNotFound,
+		},
+		{
+			msg:        "not a GET request",
+			method:     http.MethodPut,
+			response:   &api.Response{Code: http.StatusOK, Body: map[string]string{"name": "rex"}, ETag: `"v1"`},
+			header:     http.Header{"If-None-Match": []string{`"v1"`}},
+			statusCode: http.StatusOK,
+			etag:       `"v1"`,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			router := gin.New()
+			if tc.computeETags {
+				router.Use(ComputeETags())
+			}
+			router.Handle(tc.method, "/", func(c *gin.Context) {
+				WriteResponse(c, tc.response)
+			})
+
+			req := httptest.NewRequest(tc.method, "/", nil)
+			for name := range tc.header {
+				req.Header.Set(name, tc.header.Get(name))
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("expected response code %d, got %d", tc.statusCode, w.Code)
+			}
+
+			if etag := w.Header().Get("ETag"); etag != tc.etag {
+				t.Errorf("expected ETag '%s', got '%s'", tc.etag, etag)
+			}
+
+			if w.Code == http.StatusNotModified && w.Body.Len() > 0 {
+				t.Errorf("expected no body, got %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestPreconditionProblem(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	for _, tc := range []struct {
+		msg      string
+		ifMatch  string
+		etag     string
+		required bool
+		status   int
+	}{
+		{
+			msg:     "matching If-Match",
+			ifMatch: `"v0", "v1"`,
+			etag:    `"v1"`,
+		},
+		{
+			msg:     "any entity tag",
+			ifMatch: "*",
+			etag:    `"v1"`,
+		},
+		{
+			msg:     "mismatching If-Match",
+			ifMatch: `"v0"`,
+			etag:    `"v1"`,
+			status:  http.StatusPreconditionFailed,
+		},
+		{
+			msg:     "weak entity tag",
+			ifMatch: `W/"v1"`,
+			etag:    `"v1"`,
+			status:  http.StatusPreconditionFailed,
+		},
+		{
+			msg:     "resource doesn't exist",
+			ifMatch: "*",
+			status:  http.StatusPreconditionFailed,
+		},
+		{
+			msg:      "required If-Match missing",
+			etag:     `"v1"`,
+			required: true,
+			status:   http.StatusPreconditionRequired,
+		},
+		{
+			msg:  "optional If-Match missing",
+			etag: `"v1"`,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPut, "/", nil)
+			if tc.ifMatch != "" {
+				c.Request.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			problem := PreconditionProblem(c, tc.etag, tc.required)
+			if tc.status == 0 && problem != nil {
+				t.Errorf("expected no problem, got %v", problem)
+			}
+
+			if tc.status != 0 && (problem == nil || problem.Status != tc.status) {
+				t.Errorf("expected problem with status %d, got %v", tc.status, problem)
+			}
+		})
+	}
+}
@@ -0,0 +1,336 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mikkeloscar/gin-swagger/api"
+)
+
+const (
+	mediaTypeContextKey = "response_media_type"
+	producesContextKey  = "produces"
+	responseContextKey  = "response"
+	defaultMediaType    = "application/json"
+	streamMediaType     = "application/octet-stream"
+	problemMediaType    = "application/problem+json"
+)
+
+// Produces is a middleware that negotiates the media type of the response
+// based on the request Accept header and the media types the operation
+// produces. Bodies of media types without a registered api.Codec, e.g.
+// application/octet-stream, are written as provided by the Responder, see
+// WriteResponse. It responds with 406 if the Accept header excludes all of
+// the media types.
+func Produces(mediaTypes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if len(mediaTypes) == 0 {
+			c.Next()
+			return
+		}
+
+		mediaType := negotiate(c.GetHeader("Accept"), mediaTypes)
+		if mediaType == "" {
+			problem := api.Problem{
+				Title:  "Not Acceptable.",
+				Status: http.StatusNotAcceptable,
+				Detail: fmt.Sprintf("none of the accepted media types '%s' can be produced, only %s are available",
+					c.GetHeader("Accept"),
+					mediaTypes,
+				),
+			}
+			c.Writer.Header().Set("Content-Type", problemMediaType)
+			c.JSON(problem.Status, problem)
+			c.Abort()
+			return
+		}
+
+		c.Set(mediaTypeContextKey, mediaType)
+		c.Set(producesContextKey, mediaTypes)
+		c.Next()
+	}
+}
+
+// acceptRange is a media range of an Accept header with its quality.
+type acceptRange struct {
+	mediaType string
+	quality   float64
+}
+
+// negotiate returns the offer which best matches the Accept header. The
+// quality of an offer is the quality of the most specific media range
+// matching it, ties are resolved by the order of the offers. The first
+// offer is returned if the header is empty and an empty string if no offer
+// is acceptable.
+func negotiate(accept string, offers []string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+
+	ranges := parseAccept(accept)
+
+	best, bestQuality := "", 0.0
+	for _, offer := range offers {
+		quality, specificity := 0.0, -1
+		for _, r := range ranges {
+			s := mediaRangeSpecificity(r.mediaType, offer)
+			if s > specificity {
+				quality, specificity = r.quality, s
+			}
+		}
+
+		if quality > bestQuality {
+			best, bestQuality = offer, quality
+		}
+	}
+
+	return best
+}
+
+// parseAccept parses the media ranges of an Accept header.
+func parseAccept(accept string) []acceptRange {
+	var ranges []acceptRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			quality, err = strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+		}
+
+		ranges = append(ranges, acceptRange{mediaType: mediaType, quality: quality})
+	}
+
+	return ranges
+}
+
+// mediaRangeSpecificity returns how specific the media range matches the
+// media type: 2 for an exact match, 1 for a subtype wildcard such as
+// application/*, 0 for */* and -1 if it doesn't match.
+func mediaRangeSpecificity(mediaRange, mediaType string) int {
+	mediaType = strings.ToLower(mediaType)
+	switch {
+	case mediaRange == mediaType:
+		return 2
+	case mediaRange == "*/*":
+		return 0
+	case strings.HasSuffix(mediaRange, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(mediaRange, "*")):
+		return 1
+	}
+	return -1
+}
+
+// ResponseMediaType returns the media type negotiated by Produces, defaulting
+// to application/json.
+func ResponseMediaType(c *gin.Context) string {
+	mediaType := c.GetString(mediaTypeContextKey)
+	if mediaType == "" {
+		return defaultMediaType
+	}
+	return mediaType
+}
+
+// WriteResponse writes the response with the codec of the media type
+// negotiated by Produces, defaulting to application/json. If the codec
+// can't encode the body, the media type is negotiated again among the
+// codecs which can. Problem bodies are always written as
+// application/problem+json. Responses written after the deadline set by
+// Timeout are replaced with a 503 Problem.
+//
+// io.Reader bodies are streamed as they are, as are []byte and string
+// bodies if the negotiated media type has no codec, with the negotiated
+// media type, e.g. application/octet-stream.
+//
+// The body is encoded before anything is written. If encoding fails the
+// body is written as application/json instead, or a 500 Problem if that
+// fails too.
+//
+// The Header of the response is added to the headers already set on the
+// response writer, replacing headers of the same name. The ETag and
+// LastModified of the response are set as headers, and
+// successful responses to GET and HEAD requests with a matching
+// If-None-Match or If-Modified-Since are responded with 304. The ETag is
+// computed from the encoded body if enabled by ComputeETags.
+func WriteResponse(c *gin.Context, resp *api.Response) {
+	if deadlineExceeded(c) {
+		resp = &api.Response{Code: http.StatusServiceUnavailable, Body: timeoutProblem()}
+	}
+	c.Set(responseContextKey, resp)
+
+	for name, values := range resp.Header {
+		c.Writer.Header().Del(name)
+		for _, value := range values {
+			c.Writer.Header().Add(name, value)
+		}
+	}
+
+	success := resp.Code >= 200 && resp.Code < 300
+	if resp.Code == http.StatusNoContent || resp.Body == nil {
+		setValidators(c, resp.ETag, resp.LastModified)
+		if success && notModified(c.Request, resp.ETag, resp.LastModified) {
+			resp = &api.Response{Code: http.StatusNotModified}
+		}
+		c.AbortWithStatus(resp.Code)
+		return
+	}
+
+	switch body := resp.Body.(type) {
+	case api.Problem, *api.Problem:
+		c.Writer.Header().Set("Content-Type", problemMediaType)
+		c.JSON(resp.Code, resp.Body)
+		return
+	case io.Reader:
+		writeStream(c, resp, body)
+		return
+	}
+
+	contentType, body, err := encodeBody(c, resp.Body)
+	if err != nil {
+		WriteResponse(c, &api.Response{
+			Code: http.StatusInternalServerError,
+			Body: api.Problem{
+				Title:  "Internal Server Error.",
+				Status: http.StatusInternalServerError,
+				Detail: "the response could not be encoded",
+			},
+		})
+		return
+	}
+
+	etag := resp.ETag
+	if etag == "" && success && c.GetBool(computeETagsContextKey) {
+		etag = computeETag(body)
+	}
+
+	setValidators(c, etag, resp.LastModified)
+	if success && notModified(c.Request, etag, resp.LastModified) {
+		c.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+
+	c.Writer.Header().Set("Content-Type", contentType)
+	c.Status(resp.Code)
+	if _, err := c.Writer.Write(body); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// writeStream streams the io.Reader body of the response with the media
+// type negotiated by Produces, defaulting to application/octet-stream. The
+// body is closed if it's an io.Closer.
+func writeStream(c *gin.Context, resp *api.Response, body io.Reader) {
+	if closer, ok := body.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	setValidators(c, resp.ETag, resp.LastModified)
+	if resp.Code >= 200 && resp.Code < 300 && notModified(c.Request, resp.ETag, resp.LastModified) {
+		c.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+
+	mediaType := c.GetString(mediaTypeContextKey)
+	if mediaType == "" {
+		mediaType = streamMediaType
+	}
+
+	c.Writer.Header().Set("Content-Type", mediaType)
+	c.Status(resp.Code)
+	if _, err := io.Copy(c.Writer, body); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// encodeBody encodes the body for WriteResponse and returns it with its
+// Content-Type. []byte and string bodies of a negotiated media type without
+// a codec are returned as they are. If encoding fails the body is encoded
+// as application/json instead.
+func encodeBody(c *gin.Context, body interface{}) (string, []byte, error) {
+	if mediaType := c.GetString(mediaTypeContextKey); mediaType != "" {
+		if _, ok := api.LookupCodec(mediaType); !ok {
+			switch body := body.(type) {
+			case []byte:
+				return mediaType, body, nil
+			case string:
+				return mediaType, []byte(body), nil
+			}
+		}
+	}
+
+	mediaType, codec := responseCodec(c, body)
+	var buf bytes.Buffer
+	if err := codec.Encode(&buf, body); err != nil {
+		_ = c.Error(fmt.Errorf("failed to encode response as %s: %w", mediaType, err))
+
+		mediaType, codec = defaultMediaType, api.JSONCodec{}
+		buf.Reset()
+		if err := codec.Encode(&buf, body); err != nil {
+			_ = c.Error(fmt.Errorf("failed to encode response as %s: %w", mediaType, err))
+			return "", nil, err
+		}
+	}
+
+	switch codec.(type) {
+	case api.JSONCodec, api.XMLCodec, api.YAMLCodec, api.TextCodec:
+		mediaType += "; charset=utf-8"
+	}
+	return mediaType, buf.Bytes(), nil
+}
+
+// responseCodec returns the media type and codec to write the body with.
+// The media type negotiated by Produces is used if its codec can encode the
+// body, otherwise it's negotiated again among the produced media types
+// whose codec can. It defaults to application/json.
+func responseCodec(c *gin.Context, body interface{}) (string, api.Codec) {
+	mediaType := ResponseMediaType(c)
+	if codec, ok := api.LookupCodec(mediaType); ok && api.CanEncode(codec, body) {
+		return mediaType, codec
+	}
+
+	value, _ := c.Get(producesContextKey)
+	offers, _ := value.([]string)
+	encodable := make([]string, 0, len(offers))
+	for _, offer := range offers {
+		if codec, ok := api.LookupCodec(offer); ok && api.CanEncode(codec, body) {
+			encodable = append(encodable, offer)
+		}
+	}
+
+	if mediaType := negotiate(c.GetHeader("Accept"), encodable); mediaType != "" {
+		codec, _ := api.LookupCodec(mediaType)
+		return mediaType, codec
+	}
+	return defaultMediaType, api.JSONCodec{}
+}
+
+// BindBody decodes the request body with the codec registered for the
+// Content-Type of the request, defaulting to application/json.
+func BindBody(c *gin.Context, v interface{}) error {
+	mediaType := c.ContentType()
+	if mediaType == "" {
+		mediaType = defaultMediaType
+	}
+
+	codec, ok := api.LookupCodec(mediaType)
+	if !ok {
+		return fmt.Errorf("unsupported media type '%s'", mediaType)
+	}
+
+	return codec.Decode(c.Request.Body, v)
+}
@@ -0,0 +1,365 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mikkeloscar/gin-swagger/api"
+)
+
+type item struct {
+	Name string `json:"name" xml:"name" yaml:"name"`
+}
+
+func TestNegotiate(t *testing.T) {
+	offers := []string{"application/json", "application/xml"}
+
+	for _, tc := range []struct {
+		msg      string
+		accept   string
+		expected string
+	}{
+		{msg: "no accept header", accept: "", expected: "application/json"},
+		{msg: "exact match", accept: "application/xml", expected: "application/xml"},
+		{msg: "wildcard", accept: "*/*", expected: "application/json"},
+		{msg: "subtype wildcard", accept: "text/html, application/*", expected: "application/json"},
+		{msg: "quality", accept: "application/json;q=0.5, application/xml", expected: "application/xml"},
+		{msg: "excluded", accept: "application/json;q=0, */*;q=0.1", expected: "application/xml"},
+		{msg: "not acceptable", accept: "text/html", expected: ""},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			if mediaType := negotiate(tc.accept, offers); mediaType != tc.expected {
+				t.Errorf("expected media type '%s', got '%s'", tc.expected, mediaType)
+			}
+		})
+	}
+}
+
+func TestProducesMiddleware(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	for _, tc := range []struct {
+		msg         string
+		accept      string
+		statusCode  int
+		contentType string
+		body        string
+	}{
+		{
+			msg:         "default media type",
+			statusCode:  http.StatusOK,
+			contentType: "application/json; charset=utf-8",
+			body:        `{"name":"foo"}`,
+		},
+		{
+			msg:         "negotiated media type",
+			accept:      "application/x-yaml",
+			statusCode:  http.StatusOK,
+			contentType: "application/x-yaml; charset=utf-8",
+			body:        "name: foo",
+		},
+		{
+			msg:         "not acceptable",
+			accept:      "text/html",
+			statusCode:  http.StatusNotAcceptable,
+			contentType: "application/problem+json",
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			router := gin.New()
+			router.Use(Produces("application/json", "application/x-yaml", "application/octet-stream"))
+			router.GET("/items/foo", func(c *gin.Context) {
+				WriteResponse(c, &api.Response{Code: http.StatusOK, Body: &item{Name: "foo"}})
+			})
+
+			req, err := http.NewRequest("GET", "/items/foo", nil)
+			if err != nil {
+				t.Errorf("should not fail: %s", err)
+			}
+			req.Header.Set("Accept", tc.accept)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("expected response code %d, got %d", tc.statusCode, w.Code)
+			}
+
+			if contentType := w.Header().Get("Content-Type"); contentType != tc.contentType {
+				t.Errorf("expected Content-Type '%s', got '%s'", tc.contentType, contentType)
+			}
+
+			if tc.body != "" && strings.TrimSpace(w.Body.String()) != tc.body {
+				t.Errorf("expected body '%s', got '%s'", tc.body, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestProducesWithoutCodec(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	for _, tc := range []struct {
+		msg         string
+		produces    []string
+		accept      string
+		body        interface{}
+		statusCode  int
+		contentType string
+		expected    string
+	}{
+		{
+			msg:         "json suffix without accept header",
+			produces:    []string{"application/vnd.api+json"},
+			body:        &item{Name: "foo"},
+			statusCode:  http.StatusOK,
+			contentType: "application/vnd.api+json; charset=utf-8",
+			expected:    `{"name":"foo"}`,
+		},
+		{
+			msg:         "accepted json suffix",
+			produces:    []string{"application/vnd.api+json"},
+			accept:      "application/vnd.api+json",
+			body:        &item{Name: "foo"},
+			statusCode:  http.StatusOK,
+			contentType: "application/vnd.api+json; charset=utf-8",
+			expected:    `{"name":"foo"}`,
+		},
+		{
+			msg:         "octet-stream reader",
+			produces:    []string{"application/octet-stream"},
+			body:        io.NopCloser(strings.NewReader("binary")),
+			statusCode:  http.StatusOK,
+			contentType: "application/octet-stream",
+			expected:    "binary",
+		},
+		{
+			msg:         "octet-stream bytes",
+			produces:    []string{"application/octet-stream"},
+			accept:      "*/*",
+			body:        []byte("binary"),
+			statusCode:  http.StatusOK,
+			contentType: "application/octet-stream",
+			expected:    "binary",
+		},
+		{
+			msg:         "excluded octet-stream",
+			produces:    []string{"application/octet-stream"},
+			accept:      "application/json",
+			body:        []byte("binary"),
+			statusCode:  http.StatusNotAcceptable,
+			contentType: "application/problem+json",
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			router := gin.New()
+			router.Use(Produces(tc.produces...))
+			router.GET("/items/foo", func(c *gin.Context) {
+				WriteResponse(c, &api.Response{Code: http.StatusOK, Body: tc.body})
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/items/foo", nil)
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("expected response code %d, got %d", tc.statusCode, w.Code)
+			}
+
+			if contentType := w.Header().Get("Content-Type"); contentType != tc.contentType {
+				t.Errorf("expected Content-Type '%s', got '%s'", tc.contentType, contentType)
+			}
+
+			if tc.expected != "" && strings.TrimSpace(w.Body.String()) != tc.expected {
+				t.Errorf("expected body '%s', got '%s'", tc.expected, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestBindBody(t *testing.T) {
+	for _, tc := range []struct {
+		msg         string
+		contentType string
+		body        string
+		valid       bool
+	}{
+		{msg: "json", contentType: "application/json", body: `{"name": "foo"}`, valid: true},
+		{msg: "default json", body: `{"name": "foo"}`, valid: true},
+		{msg: "xml", contentType: "application/xml; charset=utf-8", body: `<item><name>foo</name></item>`, valid: true},
+		{msg: "yaml", contentType: "application/x-yaml", body: "name: foo", valid: true},
+		{msg: "json suffix", contentType: "application/merge-patch+json", body: `{"name": "foo"}`, valid: true},
+		{msg: "vendor json", contentType: "application/vnd.api+json", body: `{"name": "foo"}`, valid: true},
+		{msg: "unsupported media type", contentType: "application/msgpack", body: "foo"},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest("POST", "/items", strings.NewReader(tc.body))
+			if tc.contentType != "" {
+				ctx.Request.Header.Set("Content-Type", tc.contentType)
+			}
+
+			var body item
+			err := BindBody(ctx, &body)
+			if tc.valid {
+				if err != nil {
+					t.Fatalf("should not fail: %s", err)
+				}
+				if body.Name != "foo" {
+					t.Errorf("expected name foo, got %s", body.Name)
+				}
+			} else if err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
+
+func TestWriteResponseHeader(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	for _, tc := range []struct {
+		msg      string
+		response *api.Response
+		expected http.Header
+	}{
+		{
+			msg: "response headers",
+			response: &api.Response{
+				Code:   http.StatusCreated,
+				Body:   &item{Name: "foo"},
+				Header: http.Header{"Location": []string{"/items/foo"}},
+			},
+			expected: http.Header{
+				"Location":  []string{"/items/foo"},
+				"X-Handler": []string{"true"},
+			},
+		},
+		{
+			msg: "replaced handler header",
+			response: &api.Response{
+				Code:   http.StatusOK,
+				Body:   &item{Name: "foo"},
+				Header: http.Header{"X-Handler": []string{"false"}},
+			},
+			expected: http.Header{"X-Handler": []string{"false"}},
+		},
+		{
+			msg: "multiple values",
+			response: &api.Response{
+				Code:   http.StatusNoContent,
+				Header: http.Header{"X-Tags": []string{"a", "b"}},
+			},
+			expected: http.Header{"X-Tags": []string{"a", "b"}},
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/items/foo", func(c *gin.Context) {
+				c.Header("X-Handler", "true")
+				WriteResponse(c, tc.response)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/items/foo", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.response.Code {
+				t.Errorf("expected response code %d, got %d", tc.response.Code, w.Code)
+			}
+
+			for name, values := range tc.expected {
+				if actual := w.Header().Values(name); strings.Join(actual, ",") != strings.Join(values, ",") {
+					t.Errorf("expected header %s %v, got %v", name, values, actual)
+				}
+			}
+		})
+	}
+}
+
+type failingCodec struct {
+	api.JSONCodec
+}
+
+func (failingCodec) Encode(io.Writer, interface{}) error {
+	return errors.New("failed")
+}
+
+func TestWriteResponseEncoding(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	api.RegisterCodec("application/vnd.failing", failingCodec{})
+
+	for _, tc := range []struct {
+		msg         string
+		produces    []string
+		accept      string
+		body        interface{}
+		statusCode  int
+		contentType string
+	}{
+		{
+			msg:         "negotiated codec can't encode body",
+			produces:    []string{"application/xml", "application/json"},
+			accept:      "application/xml",
+			body:        map[string]string{"name": "foo"},
+			statusCode:  http.StatusOK,
+			contentType: "application/json; charset=utf-8",
+		},
+		{
+			msg:         "negotiated again among codecs which can encode body",
+			produces:    []string{"text/plain", "application/x-yaml", "application/json"},
+			accept:      "text/plain, application/x-yaml;q=0.5, application/json;q=0.1",
+			body:        &item{Name: "foo"},
+			statusCode:  http.StatusOK,
+			contentType: "application/x-yaml; charset=utf-8",
+		},
+		{
+			msg:         "encoding fails",
+			produces:    []string{"application/vnd.failing"},
+			body:        &item{Name: "foo"},
+			statusCode:  http.StatusOK,
+			contentType: "application/json; charset=utf-8",
+		},
+		{
+			msg:         "json encoding fails",
+			produces:    []string{"application/json"},
+			body:        make(chan int),
+			statusCode:  http.StatusInternalServerError,
+			contentType: "application/problem+json",
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			router := gin.New()
+			router.Use(Produces(tc.produces...))
+			router.GET("/items/foo", func(c *gin.Context) {
+				WriteResponse(c, &api.Response{Code: http.StatusOK, Body: tc.body})
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/items/foo", nil)
+			req.Header.Set("Accept", tc.accept)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("expected response code %d, got %d", tc.statusCode, w.Code)
+			}
+
+			if contentType := w.Header().Get("Content-Type"); contentType != tc.contentType {
+				t.Errorf("expected Content-Type '%s', got '%s'", tc.contentType, contentType)
+			}
+
+			if w.Body.Len() == 0 {
+				t.Errorf("expected a body")
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mikkeloscar/gin-swagger/api"
+)
+
+// CORSConfig configures Cross-Origin Resource Sharing of the operations.
+type CORSConfig struct {
+	// AllowedOrigins are the origins allowed to call the operations from a
+	// browser, e.g. https://example.org, or * for any origin. CORS is
+	// disabled if empty.
+	AllowedOrigins []string
+	// AllowCredentials allows requests with credentials like cookies and
+	// Authorization headers.
+	AllowCredentials bool
+	// MaxAge is the time the response to a preflight request may be
+	// cached.
+	MaxAge time.Duration
+}
+
+// Enabled returns true if any origin is allowed.
+func (c CORSConfig) Enabled() bool {
+	return len(c.AllowedOrigins) > 0
+}
+
+// allowOrigin returns the Access-Control-Allow-Origin of the origin, or an
+// empty string if the origin isn't allowed.
+func (c CORSConfig) allowOrigin(origin string) string {
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" {
+			// any origin with credentials must be echoed.
+			if c.AllowCredentials {
+				return origin
+			}
+			return "*"
+		}
+
+		if strings.EqualFold(allowed, origin) {
+			return origin
+		}
+	}
+	return ""
+}
+
+// setAllowOrigin sets the Access-Control-Allow-Origin and
+// Access-Control-Allow-Credentials headers if the origin is allowed.
+func (c CORSConfig) setAllowOrigin(ctx *gin.Context, origin string) {
+	allowOrigin := c.allowOrigin(origin)
+	if allowOrigin == "" {
+		return
+	}
+
+	ctx.Header("Access-Control-Allow-Origin", allowOrigin)
+	if c.AllowCredentials {
+		ctx.Header("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// corsExposedHeaders are the response headers set by the middlewares of
+// this package which scripts of allowed origins may read.
+var corsExposedHeaders = []string{
+	"ETag",
+	IdempotentReplayedHeader,
+	"RateLimit-Limit",
+	"RateLimit-Remaining",
+	"RateLimit-Reset",
+	"Retry-After",
+	"WWW-Authenticate",
+}
+
+// CORS is a middleware that sets the CORS headers of the responses to
+// requests from allowed origins. Scripts may read the headers set by the
+// middlewares of this package, such as ETag and the RateLimit headers, and
+// the exposedHeaders, typically the headers declared for the responses of
+// the operation.
+func CORS(config CORSConfig, exposedHeaders ...string) gin.HandlerFunc {
+	exposed := append([]string{}, corsExposedHeaders...)
+	for _, header := range exposedHeaders {
+		if !containsFold(exposed, header) {
+			exposed = append(exposed, header)
+		}
+	}
+	exposeHeaders := strings.Join(exposed, ", ")
+
+	return func(c *gin.Context) {
+		if !config.Enabled() {
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Origin")
+		if origin := c.GetHeader("Origin"); origin != "" && config.allowOrigin(origin) != "" {
+			config.setAllowOrigin(c, origin)
+			c.Header("Access-Control-Expose-Headers", exposeHeaders)
+		}
+		c.Next()
+	}
+}
+
+// CORSPreflight is the handler of the OPTIONS requests of a path with
+// operations of the methods, which accept the headers. Preflight requests
+// from allowed origins for the methods and headers are responded with 204
+// and the CORS headers, others with a 403 Problem. OPTIONS requests which
+// aren't preflight requests are responded with 204 and the Allow header.
+func CORSPreflight(config CORSConfig, methods, headers []string) gin.HandlerFunc {
+	allow := strings.Join(append(append([]string{}, methods...), http.MethodOptions), ", ")
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		requestMethod := c.GetHeader("Access-Control-Request-Method")
+		if origin == "" || requestMethod == "" {
+			c.Header("Allow", allow)
+			c.Status(http.StatusNoContent)
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Origin")
+		c.Writer.Header().Add("Vary", "Access-Control-Request-Method")
+		c.Writer.Header().Add("Vary", "Access-Control-Request-Headers")
+
+		if config.allowOrigin(origin) == "" {
+			corsProblem(c, fmt.Sprintf("the origin '%s' is not allowed", origin))
+			return
+		}
+
+		if !containsFold(methods, requestMethod) {
+			corsProblem(c, fmt.Sprintf("the method '%s' is not allowed", requestMethod))
+			return
+		}
+
+		for _, header := range strings.Split(c.GetHeader("Access-Control-Request-Headers"), ",") {
+			header = strings.TrimSpace(header)
+			if header != "" && !containsFold(headers, header) {
+				corsProblem(c, fmt.Sprintf("the header '%s' is not allowed", header))
+				return
+			}
+		}
+
+		config.setAllowOrigin(c, origin)
+		c.Header("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+		if len(headers) > 0 {
+			c.Header("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+		}
+		if config.MaxAge > 0 {
+			c.Header("Access-Control-Max-Age", strconv.Itoa(int(config.MaxAge.Seconds())))
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// corsProblem responds to a denied preflight request with a 403 Problem.
+func corsProblem(c *gin.Context, detail string) {
+	problem := api.Problem{
+		Title:  "Forbidden.",
+		Status: http.StatusForbidden,
+		Detail: detail,
+	}
+	c.Writer.Header().Set("Content-Type", problemMediaType)
+	c.JSON(problem.Status, problem)
+	c.Abort()
+}
+
+// containsFold returns true if values contains s, ignoring case.
+func containsFold(values []string, s string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCORS(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	for _, tc := range []struct {
+		msg    string
+		config CORSConfig
+		origin string
+		header http.Header
+	}{
+		{
+			msg:    "allowed origin",
+			config: CORSConfig{AllowedOrigins: []string{"https://example.org"}},
+			origin: "https://example.org",
+			header: http.Header{
+				"Access-Control-Allow-Origin":   []string{"https://example.org"},
+				"Access-Control-Expose-Headers": []string{"ETag, Idempotent-Replayed, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, Retry-After, WWW-Authenticate, Location"},
+				"Vary":                          []string{"Origin"},
+			},
+		},
+		{
+			msg:    "any origin",
+			config: CORSConfig{AllowedOrigins: []string{"*"}},
+			origin: "https://example.org",
+			header: http.Header{"Access-Control-Allow-Origin": []string{"*"}},
+		},
+		{
+			msg:    "any origin with credentials",
+			config: CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true},
+			origin: "https://example.org",
+			header: http.Header{
+				"Access-Control-Allow-Origin":      []string{"https://example.org"},
+				"Access-Control-Allow-Credentials": []string{"true"},
+			},
+		},
+		{
+			msg:    "origin not allowed",
+			config: CORSConfig{AllowedOrigins: []string{"https://example.org"}},
+			origin: "https://example.com",
+			header: http.Header{
+				"Access-Control-Allow-Origin":   []string{""},
+				"Access-Control-Expose-Headers": []string{""},
+			},
+		},
+		{
+			msg:    "disabled",
+			origin: "https://example.org",
+			header: http.Header{
+				"Access-Control-Allow-Origin": []string{""},
+				"Vary":                        []string{""},
+			},
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			router := gin.New()
+			router.Use(CORS(tc.config, "Location", "etag"))
+			router.GET("/", func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Origin", tc.origin)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			for name := range tc.header {
+				if value := w.Header().Get(name); value != tc.header.Get(name) {
+					t.Errorf("expected header %s '%s', got '%s'", name, tc.header.Get(name), value)
+				}
+			}
+		})
+	}
+}
+
+func TestCORSPreflight(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	config := CORSConfig{
+		AllowedOrigins: []string{"https://example.org"},
+		MaxAge:         time.Hour,
+	}
+
+	for _, tc := range []struct {
+		msg        string
+		header     http.Header
+		statusCode int
+		expected   http.Header
+	}{
+		{
+			msg: "allowed preflight",
+			header: http.Header{
+				"Origin":                         []string{"https://example.org"},
+				"Access-Control-Request-Method":  []string{"POST"},
+				"Access-Control-Request-Headers": []string{"authorization, content-type"},
+			},
+			statusCode: http.StatusNoContent,
+			expected: http.Header{
+				"Access-Control-Allow-Origin":  []string{"https://example.org"},
+				"Access-Control-Allow-Methods": []string{"GET, POST"},
+				"Access-Control-Allow-Headers": []string{"Authorization, Content-Type"},
+				"Access-Control-Max-Age":       []string{"3600"},
+			},
+		},
+		{
+			msg: "origin not allowed",
+			header: http.Header{
+				"Origin":                        []string{"https://example.com"},
+				"Access-Control-Request-Method": []string{"POST"},
+			},
+			statusCode: http.StatusForbidden,
+			expected:   http.Header{"Access-Control-Allow-Origin": []string{""}},
+		},
+		{
+			msg: "method not allowed",
+			header: http.Header{
+				"Origin":                        []string{"https://example.org"},
+				"Access-Control-Request-Method": []string{"DELETE"},
+			},
+			statusCode: http.StatusForbidden,
+		},
+		{
+			msg: "header not allowed",
+			header: http.Header{
+				"Origin":                         []string{"https://example.org"},
+				"Access-Control-Request-Method":  []string{"GET"},
+				"Access-Control-Request-Headers": []string{"X-Unknown"},
+			},
+			statusCode: http.StatusForbidden,
+		},
+		{
+			msg:        "not a preflight request",
+			statusCode: http.StatusNoContent,
+			expected:   http.Header{"Allow": []string{"GET, POST, OPTIONS"}},
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			router := gin.New()
+			router.OPTIONS("/", CORSPreflight(config, []string{"GET", "POST"}, []string{"Authorization", "Content-Type"}))
+
+			req := httptest.NewRequest(http.MethodOptions, "/", nil)
+			for name := range tc.header {
+				req.Header.Set(name, tc.header.Get(name))
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("expected response code %d, got %d: %s", tc.statusCode, w.Code, w.Body.String())
+			}
+
+			for name := range tc.expected {
+				if value := w.Header().Get(name); value != tc.expected.Get(name) {
+					t.Errorf("expected header %s '%s', got '%s'", name, tc.expected.Get(name), value)
+				}
+			}
+		})
+	}
+}
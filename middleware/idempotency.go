@@ -0,0 +1,290 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mikkeloscar/gin-swagger/api"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header carrying the idempotency
+	// key of a request.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses replayed for a repeated
+	// idempotency key.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// defaultIdempotencyMaxBodyBytes limits the bodies of the requests with an
+// idempotency key, which are read into memory to fingerprint them, if no
+// other limit is given.
+const defaultIdempotencyMaxBodyBytes = 10 << 20
+
+// IdempotencyRecord is the record of a request stored by idempotency key.
+type IdempotencyRecord struct {
+	// Fingerprint identifies the request the key was used for.
+	Fingerprint string
+	// Response is the response of the request, or nil while the request
+	// is in flight.
+	Response *api.Response
+}
+
+// IdempotencyStore stores the records of the requests by idempotency key.
+type IdempotencyStore interface {
+	// Reserve reserves the key for the request with the fingerprint. If
+	// the key is already reserved its record is returned and reserved is
+	// false.
+	Reserve(ctx context.Context, key, fingerprint string) (record IdempotencyRecord, reserved bool, err error)
+	// Complete stores the response of the request of a reserved key.
+	Complete(ctx context.Context, key string, response *api.Response) error
+	// Release removes the reservation of the key, such that the request
+	// can be retried.
+	Release(ctx context.Context, key string) error
+}
+
+// idempotencyRecord is a record of the MemoryIdempotencyStore.
+type idempotencyRecord struct {
+	IdempotencyRecord
+	expires time.Time
+}
+
+// MemoryIdempotencyStore is an IdempotencyStore keeping the records in
+// memory. Records expire after the TTL of the store.
+type MemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]*idempotencyRecord
+	ttl     time.Duration
+	swept   time.Time
+	now     func() time.Time
+}
+
+// NewMemoryIdempotencyStore creates a MemoryIdempotencyStore keeping the
+// records for the ttl.
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{
+		records: make(map[string]*idempotencyRecord),
+		ttl:     ttl,
+		swept:   time.Now(),
+		now:     time.Now,
+	}
+}
+
+// Reserve reserves the key for the request with the fingerprint.
+func (s *MemoryIdempotencyStore) Reserve(_ context.Context, key, fingerprint string) (IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.swept) >= s.ttl {
+		for k, record := range s.records {
+			if !now.Before(record.expires) {
+				delete(s.records, k)
+			}
+		}
+		s.swept = now
+	}
+
+	if record, ok := s.records[key]; ok && now.Before(record.expires) {
+		return record.IdempotencyRecord, false, nil
+	}
+
+	s.records[key] = &idempotencyRecord{
+		IdempotencyRecord: IdempotencyRecord{Fingerprint: fingerprint},
+		expires:           now.Add(s.ttl),
+	}
+	return IdempotencyRecord{}, true, nil
+}
+
+// Complete stores the response of the request of a reserved key.
+func (s *MemoryIdempotencyStore) Complete(_ context.Context, key string, response *api.Response) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok {
+		return fmt.Errorf("idempotency key %s is not reserved", key)
+	}
+
+	record.Response = response
+	record.expires = s.now().Add(s.ttl)
+	return nil
+}
+
+// Release removes the reservation of the key.
+func (s *MemoryIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+// Idempotency is a middleware that makes the operation safe to retry with
+// the Idempotency-Key header. The response of the first request with a key
+// is stored and replayed for repeated requests with the key, marked by the
+// Idempotent-Replayed header. Repeated requests are responded with a 409
+// Problem while the first request is in flight, and afterwards with a 422
+// Problem if the method, URL or body differ from the first request. Keys
+// are scoped to the operation and the principal of the request, or the
+// client IP address for requests without a principal.
+//
+// The stored response includes the headers set on the gin.Context by the
+// handler. Server errors and responses not written with WriteResponse aren't
+// stored, such that the request can be retried. Requests without a key, or
+// if the store fails, are passed through.
+//
+// The bodies of requests with a key are read into memory and limited to
+// maxBodyBytes, or 10 MiB if it's zero or less. Larger bodies fail like with
+// MaxBodyBytes.
+func Idempotency(store IdempotencyStore, operation string, maxBodyBytes int64) gin.HandlerFunc {
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = defaultIdempotencyMaxBodyBytes
+	}
+
+	return func(c *gin.Context) {
+		idempotencyKey := c.GetHeader(IdempotencyKeyHeader)
+		if idempotencyKey == "" {
+			c.Next()
+			return
+		}
+
+		fingerprint, err := fingerprintRequest(c, maxBodyBytes)
+		if err != nil {
+			// the error is reported when binding the body.
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		key := operation + "/" + clientKey(c) + "/" + idempotencyKey
+		record, reserved, err := store.Reserve(ctx, key, fingerprint)
+		if err != nil {
+			_ = c.Error(fmt.Errorf("failed to reserve idempotency key: %w", err))
+			c.Next()
+			return
+		}
+
+		if !reserved {
+			switch {
+			case record.Response == nil:
+				idempotencyProblem(c, http.StatusConflict, "Conflict.", "a request with the idempotency key is in flight")
+			case record.Fingerprint != fingerprint:
+				idempotencyProblem(c, http.StatusUnprocessableEntity, "Unprocessable Entity.", "the idempotency key was used for a different request")
+			default:
+				c.Header(IdempotentReplayedHeader, "true")
+				WriteResponse(c, record.Response)
+				c.Abort()
+			}
+			return
+		}
+
+		completed := false
+		// release the key if the handler panics.
+		defer func() {
+			if !completed {
+				err := store.Release(ctx, key)
+				if err != nil {
+					_ = c.Error(fmt.Errorf("failed to release idempotency key: %w", err))
+				}
+			}
+		}()
+
+		header := c.Writer.Header().Clone()
+		c.Next()
+
+		resp, ok := writtenResponse(c)
+		if !ok || resp.Code >= http.StatusInternalServerError {
+			return
+		}
+
+		err = store.Complete(ctx, key, recordedResponse(resp, header, c.Writer.Header()))
+		if err != nil {
+			_ = c.Error(fmt.Errorf("failed to store idempotent response: %w", err))
+			return
+		}
+		completed = true
+	}
+}
+
+// recordedResponse returns the response with the headers which were set on
+// the response writer by the handler, i.e. which changed from before, added
+// to the headers of the response. The Content-Type and Content-Length are
+// left to WriteResponse.
+func recordedResponse(resp *api.Response, before, after http.Header) *api.Response {
+	header := resp.Header.Clone()
+	for name, values := range after {
+		if name == "Content-Type" || name == "Content-Length" || slices.Equal(before[name], values) {
+			continue
+		}
+
+		if header == nil {
+			header = make(http.Header)
+		}
+		if _, ok := header[name]; !ok {
+			header[name] = slices.Clone(values)
+		}
+	}
+
+	recorded := *resp
+	recorded.Header = header
+	return &recorded
+}
+
+// fingerprintRequest returns a hash of the method, URL and body of the
+// request. The body is restored to be read again. Reading more than
+// maxBodyBytes of the body fails.
+func fingerprintRequest(c *gin.Context, maxBodyBytes int64) (string, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodyBytes))
+	if err != nil {
+		c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errorReader{err}))
+		return "", err
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s %s\n", c.Request.Method, c.Request.URL.RequestURI())
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// errorReader is an io.Reader failing with err.
+type errorReader struct {
+	err error
+}
+
+func (r errorReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+// writtenResponse returns the response written with WriteResponse.
+func writtenResponse(c *gin.Context) (*api.Response, bool) {
+	value, ok := c.Get(responseContextKey)
+	if !ok {
+		return nil, false
+	}
+
+	resp, ok := value.(*api.Response)
+	return resp, ok && resp != nil
+}
+
+// idempotencyProblem responds to a repeated request with a Problem.
+func idempotencyProblem(c *gin.Context, status int, title, detail string) {
+	problem := api.Problem{
+		Title:  title,
+		Status: status,
+		Detail: detail,
+	}
+	c.Writer.Header().Set("Content-Type", problemMediaType)
+	c.JSON(problem.Status, problem)
+	c.Abort()
+}
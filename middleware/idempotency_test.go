@@ -0,0 +1,228 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mikkeloscar/gin-swagger/api"
+)
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+	now := time.Now()
+	store := NewMemoryIdempotencyStore(time.Hour)
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, reserved, err := store.Reserve(ctx, "a", "fingerprint")
+	if err != nil || !reserved {
+		t.Fatalf("expected key to be reserved, got %t: %v", reserved, err)
+	}
+
+	record, reserved, err := store.Reserve(ctx, "a", "other")
+	if err != nil || reserved || record.Fingerprint != "fingerprint" || record.Response != nil {
+		t.Fatalf("expected in flight record, got %t %v: %v", reserved, record, err)
+	}
+
+	err = store.Complete(ctx, "a", &api.Response{Code: http.StatusCreated})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, reserved, err = store.Reserve(ctx, "a", "fingerprint")
+	if err != nil || reserved || record.Response == nil || record.Response.Code != http.StatusCreated {
+		t.Fatalf("expected completed record, got %t %v: %v", reserved, record, err)
+	}
+
+	now = now.Add(2 * time.Hour)
+	_, reserved, err = store.Reserve(ctx, "a", "fingerprint")
+	if err != nil || !reserved {
+		t.Fatalf("expected expired key to be reserved, got %t: %v", reserved, err)
+	}
+
+	err = store.Release(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, reserved, err = store.Reserve(ctx, "a", "fingerprint")
+	if err != nil || !reserved {
+		t.Fatalf("expected released key to be reserved, got %t: %v", reserved, err)
+	}
+}
+
+func TestIdempotency(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	type request struct {
+		key        string
+		path       string
+		body       string
+		remoteAddr string
+	}
+
+	for _, tc := range []struct {
+		msg          string
+		reserved     string
+		maxBodyBytes int64
+		requests     []request
+		statusCodes  []int
+		calls        int
+	}{
+		{
+			msg: "replayed response",
+			requests: []request{
+				{key: "a", path: "/pets", body: `{"name": "rex"}`},
+				{key: "a", path: "/pets", body: `{"name": "rex"}`},
+			},
+			statusCodes: []int{http.StatusCreated, http.StatusCreated},
+			calls:       1,
+		},
+		{
+			msg: "different keys",
+			requests: []request{
+				{key: "a", path: "/pets", body: `{"name": "rex"}`},
+				{key: "b", path: "/pets", body: `{"name": "rex"}`},
+			},
+			statusCodes: []int{http.StatusCreated, http.StatusCreated},
+			calls:       2,
+		},
+		{
+			msg: "no key",
+			requests: []request{
+				{path: "/pets", body: `{"name": "rex"}`},
+				{path: "/pets", body: `{"name": "rex"}`},
+			},
+			statusCodes: []int{http.StatusCreated, http.StatusCreated},
+			calls:       2,
+		},
+		{
+			msg: "same key of different clients",
+			requests: []request{
+				{key: "a", path: "/pets", body: `{"name": "rex"}`, remoteAddr: "192.0.2.1:1234"},
+				{key: "a", path: "/pets", body: `{"name": "rex"}`, remoteAddr: "192.0.2.2:1234"},
+			},
+			statusCodes: []int{http.StatusCreated, http.StatusCreated},
+			calls:       2,
+		},
+		{
+			msg: "key reused for different body",
+			requests: []request{
+				{key: "a", path: "/pets", body: `{"name": "rex"}`},
+				{key: "a", path: "/pets", body: `{"name": "fido"}`},
+			},
+			statusCodes: []int{http.StatusCreated, http.StatusUnprocessableEntity},
+			calls:       1,
+		},
+		{
+			msg: "key reused for different url",
+			requests: []request{
+				{key: "a", path: "/pets", body: `{"name": "rex"}`},
+				{key: "a", path: "/pets?dry_run=true", body: `{"name": "rex"}`},
+			},
+			statusCodes: []int{http.StatusCreated, http.StatusUnprocessableEntity},
+			calls:       1,
+		},
+		{
+			msg:      "request in flight",
+			reserved: "a",
+			requests: []request{
+				{key: "a", path: "/pets", body: `{"name": "rex"}`},
+			},
+			statusCodes: []int{http.StatusConflict},
+		},
+		{
+			msg:          "body too large",
+			maxBodyBytes: 8,
+			requests: []request{
+				{key: "a", path: "/pets", body: `{"name": "rex"}`},
+				{key: "a", path: "/pets", body: `{"name": "rex"}`},
+			},
+			statusCodes: []int{http.StatusRequestEntityTooLarge, http.StatusRequestEntityTooLarge},
+			calls:       2,
+		},
+		{
+			msg: "server error not stored",
+			requests: []request{
+				{key: "a", path: "/pets", body: `{"name": "error"}`},
+				{key: "a", path: "/pets", body: `{"name": "error"}`},
+			},
+			statusCodes: []int{http.StatusInternalServerError, http.StatusInternalServerError},
+			calls:       2,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			store := NewMemoryIdempotencyStore(time.Hour)
+			if tc.reserved != "" {
+				_, _, err := store.Reserve(context.Background(), "create_pet/ip:192.0.2.1/"+tc.reserved, "fingerprint")
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			calls := 0
+			router := gin.New()
+			router.Use(Idempotency(store, "create_pet", tc.maxBodyBytes))
+			router.POST("/pets", func(c *gin.Context) {
+				calls++
+
+				var body map[string]interface{}
+				err := BindBody(c, &body)
+				if err != nil {
+					problem := ValidationProblem(err)
+					WriteResponse(c, &api.Response{Code: problem.Status, Body: problem})
+					return
+				}
+
+				if body["name"] == "error" {
+					WriteResponse(c, &api.Response{Code: http.StatusInternalServerError, Body: api.Problem{Status: http.StatusInternalServerError}})
+					return
+				}
+				c.Header("Location", "/pets/rex")
+				WriteResponse(c, &api.Response{Code: http.StatusCreated, Body: body, Header: http.Header{"X-Pet": []string{"rex"}}})
+			})
+
+			for i, r := range tc.requests {
+				req := httptest.NewRequest(http.MethodPost, r.path, strings.NewReader(r.body))
+				req.Header.Set("Content-Type", "application/json")
+				if r.remoteAddr != "" {
+					req.RemoteAddr = r.remoteAddr
+				}
+				if r.key != "" {
+					req.Header.Set(IdempotencyKeyHeader, r.key)
+				}
+
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				if w.Code != tc.statusCodes[i] {
+					t.Errorf("expected response code %d of request %d, got %d: %s", tc.statusCodes[i], i, w.Code, w.Body.String())
+				}
+
+				if i > 0 && w.Code == http.StatusCreated && calls == 1 {
+					if w.Header().Get(IdempotentReplayedHeader) != "true" {
+						t.Errorf("expected replayed response of request %d", i)
+					}
+
+					if strings.TrimSpace(w.Body.String()) != `{"name":"rex"}` {
+						t.Errorf("expected replayed body, got %s", w.Body.String())
+					}
+
+					for name, value := range map[string]string{"Location": "/pets/rex", "X-Pet": "rex"} {
+						if w.Header().Get(name) != value {
+							t.Errorf("expected replayed header %s '%s', got '%s'", name, value, w.Header().Get(name))
+						}
+					}
+				}
+			}
+
+			if calls != tc.calls {
+				t.Errorf("expected %d calls of the handler, got %d", tc.calls, calls)
+			}
+		})
+	}
+}
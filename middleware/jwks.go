@@ -0,0 +1,248 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultJWKSRefreshInterval = time.Hour
+	minJWKSRefreshInterval     = 30 * time.Second
+)
+
+// JWKS is a cached JSON Web Key Set loaded from a URL or a file. The keys are
+// reloaded periodically and when a token is signed with an unknown key ID to
+// support key rotation. The key set is loaded by one request at a time,
+// while the other requests are served the cached keys.
+type JWKS struct {
+	source          string
+	refreshInterval time.Duration
+	client          *http.Client
+
+	mu sync.Mutex
+	// keys is the last loaded key set, or nil if it was never loaded.
+	keys map[string]crypto.PublicKey
+	// fetchedAt is the time of the last attempt to load the key set, also
+	// if it failed, such that a failing source is retried at most every
+	// minJWKSRefreshInterval, whether keys are cached or not.
+	fetchedAt time.Time
+	// loading is closed when the loading in progress finishes, or nil if
+	// the key set isn't being loaded.
+	loading chan struct{}
+	loadErr error
+}
+
+// NewJWKS creates a JWKS for the key set at source, which is either an
+// http(s) URL or a file path. A refreshInterval <= 0 defaults to one hour.
+func NewJWKS(source string, refreshInterval time.Duration) *JWKS {
+	if refreshInterval <= 0 {
+		refreshInterval = defaultJWKSRefreshInterval
+	}
+
+	return &JWKS{
+		source:          source,
+		refreshInterval: refreshInterval,
+		client:          &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Key returns the public key with the key ID. Only the first request
+// waits for the key set to be loaded, and requests for an unknown key ID
+// wait for it to be reloaded. Periodic reloads happen in the background
+// while the cached keys are served. After a failed load, including the
+// first, the key set is loaded again at most every minJWKSRefreshInterval
+// and the error is returned meanwhile if no keys are cached.
+func (j *JWKS) Key(kid string) (crypto.PublicKey, error) {
+	j.mu.Lock()
+	age := time.Since(j.fetchedAt)
+	_, known := j.keys[kid]
+	if age > j.refreshInterval || (!known && age > minJWKSRefreshInterval) {
+		loading := j.refresh()
+		if !known {
+			j.mu.Unlock()
+			<-loading
+			j.mu.Lock()
+		}
+	}
+	key, ok := j.keys[kid]
+	loaded, err := j.keys != nil, j.loadErr
+	j.mu.Unlock()
+
+	if !ok {
+		// keep serving the cached keys if the key set can't be refreshed.
+		if !loaded {
+			return nil, err
+		}
+		return nil, fmt.Errorf("unknown key ID '%s'", kid)
+	}
+	return key, nil
+}
+
+// refresh starts loading the key set unless it's already being loaded. It
+// returns a channel which is closed when loading finishes. j.mu must be
+// held by the caller.
+func (j *JWKS) refresh() <-chan struct{} {
+	if j.loading != nil {
+		return j.loading
+	}
+
+	loading := make(chan struct{})
+	j.loading = loading
+	go func() {
+		keys, err := j.load()
+
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		if err == nil {
+			j.keys = keys
+		}
+		j.loadErr = err
+		j.fetchedAt = time.Now()
+		j.loading = nil
+		close(loading)
+	}()
+	return loading
+}
+
+// load reads and parses the key set from the source. Keys which aren't for
+// signatures or are unsupported or invalid are skipped, as key sets may
+// contain keys for other uses. It fails if no keys are left.
+func (j *JWKS) load() (map[string]crypto.PublicKey, error) {
+	var data []byte
+	var err error
+	if strings.HasPrefix(j.source, "http://") || strings.HasPrefix(j.source, "https://") {
+		data, err = j.fetch()
+	} else {
+		data, err = os.ReadFile(j.source)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	err = json.Unmarshal(data, &set)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			log.Warnf("Skipping key '%s' of the JWKS %s: %s", jwk.Kid, j.source, err)
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no supported signature keys in the JWKS %s", j.source)
+	}
+
+	return keys, nil
+}
+
+// fetch downloads the key set from the source URL.
+func (j *JWKS) fetch() ([]byte, error) {
+	resp, err := j.client.Get(j.source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS from %s: %s", j.source, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// jsonWebKey is a public key of a JSON Web Key Set as defined by RFC 7517.
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// publicKey returns the RSA, EC or Ed25519 public key of the JWK.
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve '%s'", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve '%s'", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type '%s'", k.Kty)
+	}
+}
+
+// decodeBigInt decodes a base64url encoded big-endian integer.
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
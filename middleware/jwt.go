@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	defaultScopeClaim = "scope"
+	defaultUIDClaim   = "sub"
+)
+
+// JWTConfig configures the local validation of JWT bearer tokens.
+type JWTConfig struct {
+	// JWKSURL is the URL or file path of the JSON Web Key Set with the
+	// keys the tokens are signed with. JWT validation is disabled if not
+	// set.
+	JWKSURL string
+	// JWKSRefreshInterval is how often the key set is reloaded, one hour
+	// by default.
+	JWKSRefreshInterval time.Duration
+	// Issuer is the expected iss claim. It's not checked if empty.
+	Issuer string
+	// Audience is the expected aud claim. It's not checked if empty.
+	Audience string
+	// ScopeClaim is the claim holding the scopes of the token, either as a
+	// space separated string or a list. Defaults to scope.
+	ScopeClaim string
+	// UIDClaim is the claim used as the uid of the user, sub by default.
+	UIDClaim string
+	// RealmClaim is the claim used as the realm of the user. The realm is
+	// not set if empty.
+	RealmClaim string
+	// Leeway is the allowed clock skew when checking exp and nbf.
+	Leeway time.Duration
+}
+
+// JWTVerifier validates JWT bearer tokens against the keys of a JWKS.
+type JWTVerifier struct {
+	config JWTConfig
+	keys   *JWKS
+	parser *jwt.Parser
+}
+
+// NewJWTVerifier creates a JWTVerifier for the config.
+func NewJWTVerifier(config JWTConfig) *JWTVerifier {
+	if config.ScopeClaim == "" {
+		config.ScopeClaim = defaultScopeClaim
+	}
+
+	if config.UIDClaim == "" {
+		config.UIDClaim = defaultUIDClaim
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(config.Leeway),
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}),
+	}
+
+	if config.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(config.Issuer))
+	}
+
+	if config.Audience != "" {
+		opts = append(opts, jwt.WithAudience(config.Audience))
+	}
+
+	return &JWTVerifier{
+		config: config,
+		keys:   NewJWKS(config.JWKSURL, config.JWKSRefreshInterval),
+		parser: jwt.NewParser(opts...),
+	}
+}
+
+// Verify validates the signature, iss, aud, exp and nbf of the token and
+// returns its claims.
+func (v *JWTVerifier) Verify(token string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.Key(kid)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// Scopes returns the scopes of the configured scope claim.
+func (v *JWTVerifier) Scopes(claims jwt.MapClaims) map[string]struct{} {
+	scopes := make(map[string]struct{})
+	switch value := claims[v.config.ScopeClaim].(type) {
+	case string:
+		for _, scope := range strings.Fields(value) {
+			scopes[scope] = struct{}{}
+		}
+	case []interface{}:
+		for _, scope := range value {
+			if s, ok := scope.(string); ok {
+				scopes[s] = struct{}{}
+			}
+		}
+	}
+	return scopes
+}
+
+// JWTAuthenticator authenticates requests by the bearer token validated
+// with the verifier. Tokens without all of the specified scopes are denied
+// with 403. The uid and realm of the token are used as the principal.
+func JWTAuthenticator(verifier *JWTVerifier, scopes ...string) Authenticator {
+	return func(c *gin.Context) (*Principal, error) {
+		token, ok := bearerToken(c.Request)
+		if !ok {
+			return nil, unauthorized("Bearer", "missing bearer token")
+		}
+
+		claims, err := verifier.Verify(token)
+		if err != nil {
+			return nil, unauthorized(`Bearer error="invalid_token"`, err.Error())
+		}
+
+		principal := &Principal{Scopes: make(map[string]interface{}, len(scopes))}
+		tokenScopes := verifier.Scopes(claims)
+		for _, scope := range scopes {
+			if _, ok := tokenScopes[scope]; !ok {
+				return nil, &AuthError{
+					Status:    http.StatusForbidden,
+					Challenge: fmt.Sprintf(`Bearer error="insufficient_scope", scope="%s"`, strings.Join(scopes, " ")),
+					Detail:    fmt.Sprintf("token is missing the scope '%s'", scope),
+				}
+			}
+			principal.Scopes[scope] = true
+		}
+
+		if uid, ok := claims[verifier.config.UIDClaim].(string); ok {
+			principal.UID = uid
+		}
+
+		if verifier.config.RealmClaim != "" {
+			if realm, ok := claims[verifier.config.RealmClaim].(string); ok {
+				principal.Realm = realm
+			}
+		}
+
+		return principal, nil
+	}
+}
+
+// JWTAuth is a middleware that validates the bearer token of the request
+// with the verifier and gives access if the token includes all of the
+// specified scopes. The uid and realm of the token are set on the
+// gin.Context, see GetUser.
+func JWTAuth(verifier *JWTVerifier, scopes ...string) gin.HandlerFunc {
+	return authenticate(JWTAuthenticator(verifier, scopes...))
+}
+
+// bearerToken returns the bearer token of the Authorization header.
+func bearerToken(req *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(req.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
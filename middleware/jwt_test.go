@@ -0,0 +1,383 @@
+package middleware
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func rsaJWK(kid string, key *rsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kid": kid,
+		"kty": "RSA",
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kid": kid,
+		"kty": "EC",
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		"y":   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+	return signed
+}
+
+func TestJWTAuth(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{rsaJWK("rsa", rsaKey), ecJWK("ec", ecKey)},
+		})
+	}))
+	defer jwks.Close()
+
+	verifier := NewJWTVerifier(JWTConfig{
+		JWKSURL:    jwks.URL,
+		Issuer:     "https://issuer.example.org",
+		Audience:   "example",
+		RealmClaim: "realm",
+	})
+
+	now := time.Now()
+	claims := func(overrides jwt.MapClaims) jwt.MapClaims {
+		claims := jwt.MapClaims{
+			"iss":   "https://issuer.example.org",
+			"aud":   "example",
+			"sub":   "johndoe",
+			"realm": "/employees",
+			"scope": "uid read",
+			"exp":   now.Add(time.Hour).Unix(),
+			"nbf":   now.Add(-time.Minute).Unix(),
+		}
+		for key, value := range overrides {
+			claims[key] = value
+		}
+		return claims
+	}
+
+	for _, tc := range []struct {
+		msg        string
+		token      string
+		statusCode int
+	}{
+		{
+			msg:        "valid RSA token",
+			token:      signToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(nil)),
+			statusCode: http.StatusOK,
+		},
+		{
+			msg:        "valid EC token with scope list",
+			token:      signToken(t, jwt.SigningMethodES256, "ec", ecKey, claims(jwt.MapClaims{"scope": []string{"uid"}})),
+			statusCode: http.StatusOK,
+		},
+		{
+			msg:        "missing token",
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			msg:        "invalid issuer",
+			token:      signToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(jwt.MapClaims{"iss": "https://other.example.org"})),
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			msg:        "invalid audience",
+			token:      signToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(jwt.MapClaims{"aud": "other"})),
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			msg:        "expired token",
+			token:      signToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(jwt.MapClaims{"exp": now.Add(-time.Minute).Unix()})),
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			msg:        "token not valid yet",
+			token:      signToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(jwt.MapClaims{"nbf": now.Add(time.Hour).Unix()})),
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			msg:        "unknown key",
+			token:      signToken(t, jwt.SigningMethodRS256, "other", rsaKey, claims(nil)),
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			msg:        "missing scope",
+			token:      signToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(jwt.MapClaims{"scope": "read"})),
+			statusCode: http.StatusForbidden,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/", JWTAuth(verifier, "uid"), func(c *gin.Context) {
+				user := GetUser(c)
+				if user.UID != "johndoe" || user.Realm != "/employees" {
+					t.Errorf("unexpected user %#v", user)
+				}
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest("GET", "/", nil)
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("expected response code %d, got %d: %s", tc.statusCode, w.Code, w.Body.String())
+			}
+
+			if tc.statusCode != http.StatusOK && w.Header().Get("WWW-Authenticate") == "" {
+				t.Errorf("expected WWW-Authenticate header")
+			}
+		})
+	}
+}
+
+func TestJWKSRotation(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	var rotated atomic.Bool
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		keys := []map[string]string{rsaJWK("old", oldKey)}
+		if rotated.Load() {
+			keys = append(keys, rsaJWK("new", newKey))
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+	}))
+	defer server.Close()
+
+	jwks := NewJWKS(server.URL, 0)
+
+	_, err = jwks.Key("old")
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	_, err = jwks.Key("old")
+	if err != nil || requests.Load() != 1 {
+		t.Errorf("expected cached key set, got %d requests", requests.Load())
+	}
+
+	rotated.Store(true)
+	jwks.fetchedAt = jwks.fetchedAt.Add(-minJWKSRefreshInterval - time.Second)
+
+	_, err = jwks.Key("new")
+	if err != nil {
+		t.Errorf("expected rotated key to be loaded: %s", err)
+	}
+}
+
+func TestJWKSFile(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{rsaJWK("file", key)},
+	})
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	err = os.WriteFile(path, data, 0o644)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	_, err = NewJWKS(path, 0).Key("file")
+	if err != nil {
+		t.Errorf("should not fail: %s", err)
+	}
+}
+
+func TestJWKSUnavailable(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	var unavailable atomic.Bool
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if unavailable.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{rsaJWK("key", key)}})
+	}))
+	defer server.Close()
+
+	jwks := NewJWKS(server.URL, time.Minute)
+
+	_, err = jwks.Key("key")
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	unavailable.Store(true)
+	jwks.mu.Lock()
+	jwks.fetchedAt = jwks.fetchedAt.Add(-2 * time.Minute)
+	jwks.mu.Unlock()
+
+	for i := 0; i < 10; i++ {
+		_, err = jwks.Key("key")
+		if err != nil {
+			t.Errorf("expected cached key while the key set is unavailable: %s", err)
+		}
+	}
+
+	// wait for the background refresh to fail.
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		jwks.mu.Lock()
+		loading := jwks.loading
+		jwks.mu.Unlock()
+		if loading == nil {
+			break
+		}
+	}
+
+	_, err = jwks.Key("key")
+	if err != nil {
+		t.Errorf("expected cached key after the refresh failed: %s", err)
+	}
+
+	if requests.Load() != 2 {
+		t.Errorf("expected a single refresh of the unavailable key set, got %d requests", requests.Load()-1)
+	}
+}
+
+func TestJWKSMixedKeys(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	for _, tc := range []struct {
+		msg   string
+		keys  []map[string]string
+		valid bool
+	}{
+		{
+			msg: "unsupported keys are skipped",
+			keys: []map[string]string{
+				{"kid": "x25519", "kty": "OKP", "crv": "X25519", "x": "AA"},
+				{"kid": "secp256k1", "kty": "EC", "crv": "secp256k1", "x": "AA", "y": "AA"},
+				{"kid": "oct", "kty": "oct", "k": "AA"},
+				{"kid": "enc", "kty": "RSA", "use": "enc", "n": "AA", "e": "AQAB"},
+				rsaJWK("key", key),
+			},
+			valid: true,
+		},
+		{
+			msg: "no supported keys",
+			keys: []map[string]string{
+				{"kid": "x25519", "kty": "OKP", "crv": "X25519", "x": "AA"},
+			},
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			data, err := json.Marshal(map[string]interface{}{"keys": tc.keys})
+			if err != nil {
+				t.Fatalf("should not fail: %s", err)
+			}
+
+			path := filepath.Join(t.TempDir(), "jwks.json")
+			err = os.WriteFile(path, data, 0o644)
+			if err != nil {
+				t.Fatalf("should not fail: %s", err)
+			}
+
+			_, err = NewJWKS(path, 0).Key("key")
+			if tc.valid && err != nil {
+				t.Errorf("should not fail: %s", err)
+			}
+			if !tc.valid && err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestJWKSFirstLoadFails(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	jwks := NewJWKS(server.URL, 0)
+
+	for i := 0; i < 10; i++ {
+		_, err := jwks.Key("key")
+		if err == nil {
+			t.Error("expected error while the key set is unavailable")
+		}
+	}
+
+	if requests.Load() != 1 {
+		t.Errorf("expected a single request until the refresh interval passed, got %d", requests.Load())
+	}
+
+	jwks.mu.Lock()
+	jwks.fetchedAt = jwks.fetchedAt.Add(-minJWKSRefreshInterval - time.Second)
+	jwks.mu.Unlock()
+
+	_, _ = jwks.Key("key")
+	if requests.Load() != 2 {
+		t.Errorf("expected the key set to be loaded again, got %d requests", requests.Load())
+	}
+}
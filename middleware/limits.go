@@ -0,0 +1,241 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mikkeloscar/gin-swagger/api"
+)
+
+// timeoutGrace is the time left after the deadline of a request for writing
+// its response before the connection is closed.
+const timeoutGrace = 5 * time.Second
+
+// Timeout is a middleware that sets a deadline of timeout on the request
+// context. The read and write deadlines of the connection are extended to
+// the deadline if the timeouts of the server are shorter, such that
+// operations taking longer than them aren't cut off. A timeout of zero or
+// less sets no deadline.
+//
+// Like http.TimeoutHandler the response is buffered until the handlers
+// return. If they don't return before the deadline a 503 Problem is written
+// at the deadline, also if the handlers ignore the context, and anything
+// they write afterwards is discarded.
+func Timeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		// not every ResponseWriter supports deadlines, e.g. the
+		// httptest.ResponseRecorder, in which case there's nothing to move.
+		if server, ok := c.Request.Context().Value(http.ServerContextKey).(*http.Server); ok {
+			controller := http.NewResponseController(c.Writer)
+			deadline := timeout + timeoutGrace
+			if server.ReadTimeout > 0 && server.ReadTimeout < deadline {
+				_ = controller.SetReadDeadline(time.Now().Add(deadline))
+			}
+			if server.WriteTimeout > 0 && server.WriteTimeout < deadline {
+				_ = controller.SetWriteDeadline(time.Now().Add(deadline))
+			}
+		}
+
+		w := &timeoutWriter{
+			ResponseWriter: c.Writer,
+			header:         c.Writer.Header().Clone(),
+			size:           -1,
+		}
+		timer := time.AfterFunc(timeout, w.timeout)
+		c.Writer = w
+		defer func() {
+			timer.Stop()
+			c.Writer = w.ResponseWriter
+			w.finish()
+		}()
+
+		c.Next()
+	}
+}
+
+// timeoutWriter buffers the response of the handlers until they finish, or
+// writes a 503 Problem if they don't finish before the deadline.
+type timeoutWriter struct {
+	gin.ResponseWriter
+
+	mu       sync.Mutex
+	header   http.Header
+	status   int
+	size     int
+	body     bytes.Buffer
+	timedOut bool
+	finished bool
+}
+
+// Header returns the buffered headers of the response.
+func (w *timeoutWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader sets the status code of the response.
+func (w *timeoutWriter) WriteHeader(code int) {
+	if code > 0 && w.size < 0 {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow marks the headers as written.
+func (w *timeoutWriter) WriteHeaderNow() {
+	if w.size < 0 {
+		w.size = 0
+	}
+}
+
+// Write buffers the data unless the deadline is exceeded, in which case it
+// fails with http.ErrHandlerTimeout.
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+
+	w.WriteHeaderNow()
+	n, err := w.body.Write(data)
+	w.size += n
+	return n, err
+}
+
+// WriteString buffers the string, see Write.
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Status returns the status code of the response.
+func (w *timeoutWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+// Size returns the number of bytes of the body, or -1 if the headers
+// aren't written.
+func (w *timeoutWriter) Size() int {
+	return w.size
+}
+
+// Written returns true if the headers are written.
+func (w *timeoutWriter) Written() bool {
+	return w.size >= 0
+}
+
+// Flush does nothing as the response is buffered.
+func (w *timeoutWriter) Flush() {}
+
+// timeout writes a 503 Problem unless the handlers finished.
+func (w *timeoutWriter) timeout() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.finished {
+		return
+	}
+	w.timedOut = true
+
+	body, err := json.Marshal(timeoutProblem())
+	if err != nil {
+		return
+	}
+
+	w.ResponseWriter.Header().Set("Content-Type", problemMediaType)
+	w.ResponseWriter.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.ResponseWriter.WriteHeader(http.StatusServiceUnavailable)
+	_, _ = w.ResponseWriter.Write(body)
+	w.ResponseWriter.Flush()
+}
+
+// finish writes the buffered response unless the deadline was exceeded.
+func (w *timeoutWriter) finish() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.finished = true
+	if w.timedOut {
+		return
+	}
+
+	header := w.ResponseWriter.Header()
+	for name := range header {
+		if _, ok := w.header[name]; !ok {
+			header.Del(name)
+		}
+	}
+	for name, values := range w.header {
+		header[name] = values
+	}
+
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	if w.size >= 0 {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+	if w.body.Len() > 0 {
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+	}
+}
+
+// deadlineExceeded returns true if the deadline of the request set by
+// Timeout is exceeded.
+func deadlineExceeded(c *gin.Context) bool {
+	return c.Request.Context().Err() == context.DeadlineExceeded
+}
+
+// timeoutProblem is the Problem responded with if the deadline of the
+// request is exceeded.
+func timeoutProblem() api.Problem {
+	return api.Problem{
+		Title:  "Service Unavailable.",
+		Status: http.StatusServiceUnavailable,
+		Detail: "the request exceeded its deadline",
+	}
+}
+
+// MaxBodyBytes is a middleware that limits the size of the request body to
+// n bytes. Requests with a larger Content-Length are responded with 413,
+// and reading more than n bytes of a body of unknown length fails, which
+// the request validation responds with 413. A limit of zero or less sets
+// no limit.
+func MaxBodyBytes(n int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if n <= 0 {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > n {
+			problem := api.Problem{
+				Title:  "Request Entity Too Large.",
+				Status: http.StatusRequestEntityTooLarge,
+				Detail: fmt.Sprintf("the request body exceeds the limit of %d bytes", n),
+			}
+			c.Writer.Header().Set("Content-Type", problemMediaType)
+			c.JSON(problem.Status, problem)
+			c.Abort()
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, n)
+		c.Next()
+	}
+}
@@ -0,0 +1,205 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mikkeloscar/gin-swagger/api"
+)
+
+func TestTimeout(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	for _, tc := range []struct {
+		msg         string
+		timeout     time.Duration
+		delay       time.Duration
+		statusCode  int
+		hasDeadline bool
+	}{
+		{
+			msg:         "within deadline",
+			timeout:     time.Second,
+			statusCode:  http.StatusOK,
+			hasDeadline: true,
+		},
+		{
+			msg:         "deadline exceeded",
+			timeout:     10 * time.Millisecond,
+			delay:       50 * time.Millisecond,
+			statusCode:  http.StatusServiceUnavailable,
+			hasDeadline: true,
+		},
+		{
+			msg:        "no timeout",
+			delay:      10 * time.Millisecond,
+			statusCode: http.StatusOK,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			router := gin.New()
+			router.Use(Timeout(tc.timeout))
+			router.GET("/", func(c *gin.Context) {
+				if _, ok := c.Request.Context().Deadline(); ok != tc.hasDeadline {
+					t.Errorf("expected deadline %t, got %t", tc.hasDeadline, ok)
+				}
+
+				select {
+				case <-c.Request.Context().Done():
+				case <-time.After(tc.delay):
+				}
+				WriteResponse(c, &api.Response{Code: http.StatusOK, Body: "ok"})
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if w.Code != tc.statusCode {
+				t.Errorf("expected response code %d, got %d", tc.statusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestTimeoutIgnoredContext(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	release := make(chan struct{})
+	router := gin.New()
+	router.Use(Timeout(20 * time.Millisecond))
+	router.GET("/", func(c *gin.Context) {
+		c.Header("X-Handler", "true")
+		<-release
+		WriteResponse(c, &api.Response{Code: http.StatusOK, Body: "ok"})
+	})
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+	defer close(release)
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+	defer resp.Body.Close()
+
+	var problem api.Problem
+	err = json.NewDecoder(resp.Body).Decode(&problem)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusServiceUnavailable || problem.Status != http.StatusServiceUnavailable {
+		t.Errorf("expected response code %d, got %d", http.StatusServiceUnavailable, resp.StatusCode)
+	}
+
+	if resp.Header.Get("X-Handler") != "" {
+		t.Errorf("expected headers of the handler to be discarded")
+	}
+}
+
+func TestTimeoutLongerThanWriteTimeout(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	router := gin.New()
+	router.Use(Timeout(time.Second))
+	router.GET("/", func(c *gin.Context) {
+		time.Sleep(100 * time.Millisecond)
+		WriteResponse(c, &api.Response{Code: http.StatusOK, Body: "ok"})
+	})
+
+	server := httptest.NewUnstartedServer(router)
+	server.Config.WriteTimeout = 20 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected the write deadline to be extended: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK || strings.TrimSpace(string(body)) != `"ok"` {
+		t.Errorf("expected response code %d, got %d: %s", http.StatusOK, resp.StatusCode, body)
+	}
+}
+
+func TestMaxBodyBytes(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	for _, tc := range []struct {
+		msg           string
+		limit         int64
+		body          string
+		unknownLength bool
+		statusCode    int
+	}{
+		{
+			msg:        "within limit",
+			limit:      10,
+			body:       `{"a": 1}`,
+			statusCode: http.StatusOK,
+		},
+		{
+			msg:        "content length exceeds limit",
+			limit:      4,
+			body:       `{"a": 1}`,
+			statusCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			msg:           "body of unknown length exceeds limit",
+			limit:         4,
+			body:          `{"a": 1}`,
+			unknownLength: true,
+			statusCode:    http.StatusRequestEntityTooLarge,
+		},
+		{
+			msg:        "no limit",
+			body:       `{"a": 1}`,
+			statusCode: http.StatusOK,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			router := gin.New()
+			router.Use(MaxBodyBytes(tc.limit))
+			router.POST("/", func(c *gin.Context) {
+				var body map[string]interface{}
+				err := BindBody(c, &body)
+				if err != nil {
+					problem := ValidationProblem(err)
+					WriteResponse(c, &api.Response{Code: problem.Status, Body: problem})
+					return
+				}
+				WriteResponse(c, &api.Response{Code: http.StatusOK, Body: body})
+			})
+
+			var body io.Reader = strings.NewReader(tc.body)
+			if tc.unknownLength {
+				body = io.MultiReader(body)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/", body)
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("expected response code %d, got %d: %s", tc.statusCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
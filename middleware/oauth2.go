@@ -1,10 +1,21 @@
 package middleware
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	ginoauth2 "github.com/zalando/gin-oauth2"
 )
 
+// tokenInfoClient is the client used to request the token info of oauth2
+// tokens.
+var tokenInfoClient = &http.Client{Timeout: 10 * time.Second}
+
 // ScopesAuth is an AccessCheckFunction that gives access if the token includes
 // all of the specified scopes.
 func ScopesAuth(scopes ...string) ginoauth2.AccessCheckFunction {
@@ -22,32 +33,116 @@ func ScopesAuth(scopes ...string) ginoauth2.AccessCheckFunction {
 			}
 			ctx.Set(scope, value)
 		}
-		// set uid and realm
-		// ctx.Set("uid")
 		return true
 	}
 }
 
+// TokenInfoAuthenticator authenticates requests by the bearer token
+// validated with the token info endpoint at tokenURL. Tokens without all of
+// the specified scopes are denied with 403. The uid and realm of the token
+// info are used as the principal.
+func TokenInfoAuthenticator(tokenURL string, scopes ...string) Authenticator {
+	return func(c *gin.Context) (*Principal, error) {
+		token, ok := bearerToken(c.Request)
+		if !ok {
+			return nil, unauthorized("Bearer", "missing bearer token")
+		}
+
+		info, err := requestTokenInfo(c, tokenURL, token)
+		if err != nil {
+			return nil, unauthorized(`Bearer error="invalid_token"`, err.Error())
+		}
+
+		granted := make(map[string]interface{})
+		switch value := info["scope"].(type) {
+		case string:
+			for _, scope := range strings.Fields(value) {
+				granted[scope] = true
+			}
+		case []interface{}:
+			for _, scope := range value {
+				if s, ok := scope.(string); ok {
+					granted[s] = true
+				}
+			}
+		}
+
+		principal := &Principal{Scopes: make(map[string]interface{}, len(scopes))}
+		for _, scope := range scopes {
+			if _, ok := granted[scope]; !ok {
+				return nil, &AuthError{
+					Status:    http.StatusForbidden,
+					Challenge: fmt.Sprintf(`Bearer error="insufficient_scope", scope="%s"`, strings.Join(scopes, " ")),
+					Detail:    fmt.Sprintf("token is missing the scope '%s'", scope),
+				}
+			}
+
+			// the token info holds the value of a scope in a member
+			// named after it.
+			value, ok := info[scope]
+			if !ok {
+				value = true
+			}
+			principal.Scopes[scope] = value
+		}
+
+		principal.UID, _ = info["uid"].(string)
+		principal.Realm, _ = info["realm"].(string)
+
+		return principal, nil
+	}
+}
+
+// requestTokenInfo requests the token info of the token from tokenURL.
+func requestTokenInfo(c *gin.Context, tokenURL, token string) (map[string]interface{}, error) {
+	infoURL, err := url.Parse(tokenURL)
+	if err != nil {
+		return nil, err
+	}
+
+	query := infoURL.Query()
+	query.Set("access_token", token)
+	infoURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(c.Request.Context(), http.MethodGet, infoURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := tokenInfoClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request token info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var info map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode token info: %w", err)
+	}
+
+	if description, ok := info["error_description"].(string); ok {
+		return nil, fmt.Errorf("invalid token: %s", description)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token info request failed with status %d", resp.StatusCode)
+	}
+
+	return info, nil
+}
+
 // User defines a user with UID and Realm.
 type User struct {
 	UID   string
 	Realm string
 }
 
-// GetUser gets user (uid and realm) from a gin context.
+// GetUser gets user (uid and realm) from a gin context. Values which are not
+// strings are ignored.
 func GetUser(ctx *gin.Context) User {
-	user := User{}
-	uid, ok := ctx.Get("uid")
-	if !ok {
-		return user
-	}
-	user.UID = uid.(string)
-
-	realm, ok := ctx.Get("realm")
-	if !ok {
-		return user
+	return User{
+		UID:   ctx.GetString("uid"),
+		Realm: ctx.GetString("realm"),
 	}
-	user.Realm = realm.(string)
-
-	return user
 }
@@ -56,3 +56,34 @@ func TestScopesAuth(t *testing.T) {
 		})
 	}
 }
+
+func TestGetUser(t *testing.T) {
+	for _, tc := range []struct {
+		msg  string
+		keys map[any]any
+		user User
+	}{
+		{
+			msg:  "uid and realm",
+			keys: map[any]any{"uid": "johndoe", "realm": "/employees"},
+			user: User{UID: "johndoe", Realm: "/employees"},
+		},
+		{
+			msg:  "realm without uid",
+			keys: map[any]any{"realm": "/employees"},
+			user: User{Realm: "/employees"},
+		},
+		{
+			msg:  "uid set by a scope",
+			keys: map[any]any{"uid": true},
+			user: User{},
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			user := GetUser(&gin.Context{Keys: tc.keys})
+			if user != tc.user {
+				t.Errorf("expected user %#v, got %#v", tc.user, user)
+			}
+		})
+	}
+}
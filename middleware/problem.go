@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	stderrors "errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-openapi/errors"
+	"github.com/mikkeloscar/gin-swagger/api"
+)
+
+// constraints maps the codes of go-openapi validation errors to the name of
+// the failed constraint.
+var constraints = map[int32]string{
+	errors.InvalidTypeCode:              "type",
+	errors.RequiredFailCode:             "required",
+	errors.TooLongFailCode:              "maxLength",
+	errors.TooShortFailCode:             "minLength",
+	errors.PatternFailCode:              "pattern",
+	errors.EnumFailCode:                 "enum",
+	errors.MultipleOfFailCode:           "multipleOf",
+	errors.MaxFailCode:                  "maximum",
+	errors.MinFailCode:                  "minimum",
+	errors.UniqueFailCode:               "uniqueItems",
+	errors.MaxItemsFailCode:             "maxItems",
+	errors.MinItemsFailCode:             "minItems",
+	errors.NoAdditionalItemsCode:        "additionalItems",
+	errors.TooFewPropertiesCode:         "minProperties",
+	errors.TooManyPropertiesCode:        "maxProperties",
+	errors.UnallowedPropertyCode:        "additionalProperties",
+	errors.FailedAllPatternPropsCode:    "patternProperties",
+	errors.MultipleOfMustBePositiveCode: "multipleOf",
+	errors.ReadOnlyFailCode:             "readOnly",
+}
+
+// ValidationProblem creates the 422 Problem response for a failed request
+// validation, or the 4xx of the go-openapi error, titled by its status
+// text. Each go-openapi validation error in err is added as an invalid
+// param.
+func ValidationProblem(err error) api.Problem {
+	if bodyTooLarge(err) {
+		return api.Problem{
+			Title:  http.StatusText(http.StatusRequestEntityTooLarge),
+			Status: http.StatusRequestEntityTooLarge,
+			Detail: err.Error(),
+		}
+	}
+
+	status := http.StatusUnprocessableEntity
+	if apiErr, ok := err.(errors.Error); ok && apiErr.Code() < 600 {
+		status = int(apiErr.Code())
+	}
+
+	return api.Problem{
+		Title:         http.StatusText(status),
+		Status:        status,
+		Detail:        err.Error(),
+		InvalidParams: invalidParams(err),
+	}
+}
+
+// bodyTooLarge returns true if err is caused by a request body exceeding
+// the limit of MaxBodyBytes.
+func bodyTooLarge(err error) bool {
+	switch e := err.(type) {
+	case *errors.CompositeError:
+		for _, err := range e.Errors {
+			if bodyTooLarge(err) {
+				return true
+			}
+		}
+		return false
+	case *errors.ParseError:
+		return bodyTooLarge(e.Reason)
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	return stderrors.As(err, &maxBytesErr)
+}
+
+// invalidParams flattens the validation errors into invalid params.
+func invalidParams(err error) []api.InvalidParam {
+	switch e := err.(type) {
+	case *errors.CompositeError:
+		var params []api.InvalidParam
+		for _, err := range e.Errors {
+			params = append(params, invalidParams(err)...)
+		}
+		return params
+	case *errors.Validation:
+		return []api.InvalidParam{{
+			Name:       e.Name,
+			In:         e.In,
+			Pointer:    bodyPointer(e.Name, e.In),
+			Constraint: constraints[e.Code()],
+			Message:    e.Error(),
+		}}
+	case *errors.ParseError:
+		return []api.InvalidParam{{
+			Name:       e.Name,
+			In:         e.In,
+			Pointer:    bodyPointer(e.Name, e.In),
+			Constraint: "type",
+			Message:    e.Error(),
+		}}
+	case nil:
+		return nil
+	default:
+		return []api.InvalidParam{{Message: err.Error()}}
+	}
+}
+
+// bodyPointer returns the JSON pointer of a body property from its
+// validation name, e.g. /node_pools/0/name for node_pools.0.name.
+func bodyPointer(name, in string) string {
+	if in != "body" || name == "" {
+		return ""
+	}
+
+	replacer := strings.NewReplacer("~", "~0", "/", "~1")
+	segments := strings.Split(name, ".")
+	for i, segment := range segments {
+		segments[i] = replacer.Replace(segment)
+	}
+
+	return "/" + strings.Join(segments, "/")
+}
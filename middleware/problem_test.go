@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/errors"
+	"github.com/mikkeloscar/gin-swagger/api"
+)
+
+func TestValidationProblem(t *testing.T) {
+	for _, tc := range []struct {
+		msg           string
+		err           error
+		status        int
+		title         string
+		invalidParams []api.InvalidParam
+	}{
+		{
+			msg: "parameter validation errors",
+			err: errors.CompositeValidationError(
+				errors.Required("name", "query", nil),
+				errors.FailedPattern("cluster_id", "path", "^[a-z]+$", "1"),
+			),
+			status: http.StatusUnprocessableEntity,
+			title:  "Unprocessable Entity",
+			invalidParams: []api.InvalidParam{
+				{Name: "name", In: "query", Constraint: "required", Message: "name in query is required"},
+				{Name: "cluster_id", In: "path", Constraint: "pattern", Message: "cluster_id in path should match '^[a-z]+$'"},
+			},
+		},
+		{
+			msg: "nested body validation errors",
+			err: errors.CompositeValidationError(
+				errors.CompositeValidationError(
+					errors.TooShort("node_pools.0.name", "body", 3, "ab"),
+				),
+			),
+			status: http.StatusUnprocessableEntity,
+			title:  "Unprocessable Entity",
+			invalidParams: []api.InvalidParam{
+				{Name: "node_pools.0.name", In: "body", Pointer: "/node_pools/0/name", Constraint: "minLength", Message: "node_pools.0.name in body should be at least 3 chars long"},
+			},
+		},
+		{
+			msg: "parse error",
+			err: errors.CompositeValidationError(
+				errors.NewParseError("cluster", "body", "", fmt.Errorf("unexpected EOF")),
+			),
+			status: http.StatusUnprocessableEntity,
+			title:  "Unprocessable Entity",
+			invalidParams: []api.InvalidParam{
+				{Name: "cluster", In: "body", Pointer: "/cluster", Constraint: "type", Message: "parsing cluster body from \"\" failed, because unexpected EOF"},
+			},
+		},
+		{
+			msg:           "bad request",
+			err:           errors.New(http.StatusBadRequest, "invalid header"),
+			status:        http.StatusBadRequest,
+			title:         "Bad Request",
+			invalidParams: []api.InvalidParam{{Message: "invalid header"}},
+		},
+		{
+			msg:           "other error",
+			err:           fmt.Errorf("failed"),
+			status:        http.StatusUnprocessableEntity,
+			title:         "Unprocessable Entity",
+			invalidParams: []api.InvalidParam{{Message: "failed"}},
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			problem := ValidationProblem(tc.err)
+			if problem.Status != tc.status {
+				t.Errorf("expected status %d, got %d", tc.status, problem.Status)
+			}
+
+			if problem.Title != tc.title {
+				t.Errorf("expected title '%s', got '%s'", tc.title, problem.Title)
+			}
+
+			if !reflect.DeepEqual(problem.InvalidParams, tc.invalidParams) {
+				t.Errorf("expected invalid params %#v, got %#v", tc.invalidParams, problem.InvalidParams)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mikkeloscar/gin-swagger/api"
+)
+
+// RateLimitKey selects what the requests are rate limited by.
+type RateLimitKey string
+
+const (
+	// RateLimitKeyIP limits the requests by the client IP.
+	RateLimitKeyIP RateLimitKey = "ip"
+	// RateLimitKeyPrincipal limits the requests by the principal
+	// authenticated by the security requirements of the operation, in
+	// addition to the client IP.
+	RateLimitKeyPrincipal RateLimitKey = "principal"
+)
+
+// Rate is a rate limit of Requests per Per duration, e.g. 100 requests per
+// minute. The requests are limited by the client IP if no Key is set.
+type Rate struct {
+	Requests int
+	Per      time.Duration
+	Key      RateLimitKey
+}
+
+// RateLimitResult is the result of taking a token from a bucket.
+type RateLimitResult struct {
+	// Allowed is true if a token was taken.
+	Allowed bool
+	// Remaining is the number of tokens left in the bucket.
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next token is available if the
+	// request is not allowed.
+	RetryAfter time.Duration
+}
+
+// RateLimitStore stores the token buckets of the rate limited keys. A
+// bucket holds up to rate.Requests tokens and is refilled at rate.Requests
+// tokens per rate.Per.
+type RateLimitStore interface {
+	// Take takes a token from the bucket of the key.
+	Take(ctx context.Context, key string, rate Rate) (RateLimitResult, error)
+}
+
+// bucketSweepInterval is the interval at which the MemoryRateLimitStore
+// removes buckets which are full again.
+const bucketSweepInterval = time.Minute
+
+// bucket is a token bucket of the MemoryRateLimitStore.
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	per     time.Duration
+}
+
+// MemoryRateLimitStore is a RateLimitStore keeping the buckets in memory.
+// Buckets which are full again are removed periodically, as they are
+// equivalent to a new bucket.
+type MemoryRateLimitStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+	now     func() time.Time
+}
+
+// NewMemoryRateLimitStore creates a MemoryRateLimitStore.
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*bucket),
+		swept:   time.Now(),
+		now:     time.Now,
+	}
+}
+
+// Take takes a token from the bucket of the key.
+func (s *MemoryRateLimitStore) Take(_ context.Context, key string, rate Rate) (RateLimitResult, error) {
+	if rate.Requests <= 0 || rate.Per <= 0 {
+		return RateLimitResult{}, fmt.Errorf("invalid rate of %d requests per %s", rate.Requests, rate.Per)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.swept) >= bucketSweepInterval {
+		for k, b := range s.buckets {
+			if now.Sub(b.updated) >= b.per {
+				delete(s.buckets, k)
+			}
+		}
+		s.swept = now
+	}
+
+	capacity := float64(rate.Requests)
+	// tokens refilled per second.
+	refill := capacity / rate.Per.Seconds()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		s.buckets[key] = b
+	}
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.updated).Seconds()*refill)
+	b.updated = now
+	b.per = rate.Per
+
+	result := RateLimitResult{}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - b.tokens) / refill)
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = seconds((capacity - b.tokens) / refill)
+
+	return result, nil
+}
+
+// seconds converts seconds to a time.Duration.
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// RateLimit is a middleware that limits the requests of the operation to
+// the rate by the client IP, with a bucket per operation and IP in the
+// store. The RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset
+// headers are set on the responses, and requests over the limit are
+// responded with a 429 Problem and a Retry-After header. Requests are let
+// through if the store fails. A rate without requests or period sets no
+// limit.
+//
+// RateLimit must be used before the security requirements of the operation
+// such that failed authentication attempts are limited as well.
+func RateLimit(store RateLimitStore, operation string, rate Rate) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rateLimit(c, store, operation+"/ip:"+c.ClientIP(), rate)
+	}
+}
+
+// PrincipalRateLimit is a middleware like RateLimit which limits the
+// requests of the operation by their principal if the rate is keyed by
+// the principal. Requests without a principal are left to RateLimit.
+//
+// PrincipalRateLimit must be used after the security requirements of the
+// operation.
+func PrincipalRateLimit(store RateLimitStore, operation string, rate Rate) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := principalKey(c)
+		if rate.Key != RateLimitKeyPrincipal || principal == "" {
+			c.Next()
+			return
+		}
+
+		rateLimit(c, store, operation+"/"+principal, rate)
+	}
+}
+
+// rateLimit takes a token of the rate from the bucket of the key and
+// responds with a 429 Problem if there is none left.
+func rateLimit(c *gin.Context, store RateLimitStore, key string, rate Rate) {
+	if rate.Requests <= 0 || rate.Per <= 0 {
+		c.Next()
+		return
+	}
+
+	result, err := store.Take(c.Request.Context(), key, rate)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("failed to rate limit request: %w", err))
+		c.Next()
+		return
+	}
+
+	c.Header("RateLimit-Limit", strconv.Itoa(rate.Requests))
+	c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Header("RateLimit-Reset", ceilSeconds(result.Reset))
+
+	if !result.Allowed {
+		c.Header("Retry-After", ceilSeconds(result.RetryAfter))
+		problem := api.Problem{
+			Title:  "Too Many Requests.",
+			Status: http.StatusTooManyRequests,
+			Detail: fmt.Sprintf("the rate limit of %d requests per %s is exceeded", rate.Requests, rate.Per),
+		}
+		c.Writer.Header().Set("Content-Type", problemMediaType)
+		c.JSON(problem.Status, problem)
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
+
+// ceilSeconds formats the duration as whole seconds, rounded up.
+func ceilSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
@@ -0,0 +1,204 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMemoryRateLimitStore(t *testing.T) {
+	now := time.Now()
+	store := NewMemoryRateLimitStore()
+	store.now = func() time.Time { return now }
+
+	rate := Rate{Requests: 2, Per: time.Minute}
+	for _, tc := range []struct {
+		msg        string
+		elapsed    time.Duration
+		key        string
+		allowed    bool
+		remaining  int
+		retryAfter time.Duration
+	}{
+		{
+			msg:       "full bucket",
+			key:       "a",
+			allowed:   true,
+			remaining: 1,
+		},
+		{
+			msg:     "last token",
+			key:     "a",
+			allowed: true,
+		},
+		{
+			msg:        "empty bucket",
+			key:        "a",
+			retryAfter: 30 * time.Second,
+		},
+		{
+			msg:       "other key",
+			key:       "b",
+			allowed:   true,
+			remaining: 1,
+		},
+		{
+			msg:     "refilled token",
+			elapsed: 30 * time.Second,
+			key:     "a",
+			allowed: true,
+		},
+		{
+			msg:       "bucket full again",
+			elapsed:   2 * time.Minute,
+			key:       "a",
+			allowed:   true,
+			remaining: 1,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			now = now.Add(tc.elapsed)
+
+			result, err := store.Take(context.Background(), tc.key, rate)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result.Allowed != tc.allowed {
+				t.Errorf("expected allowed %t, got %t", tc.allowed, result.Allowed)
+			}
+
+			if result.Remaining != tc.remaining {
+				t.Errorf("expected %d remaining, got %d", tc.remaining, result.Remaining)
+			}
+
+			if result.RetryAfter != tc.retryAfter {
+				t.Errorf("expected retry after %s, got %s", tc.retryAfter, result.RetryAfter)
+			}
+		})
+	}
+
+	if len(store.buckets) != 1 {
+		t.Errorf("expected the full bucket of b to be removed, got %d buckets", len(store.buckets))
+	}
+}
+
+// failingStore is a RateLimitStore which always fails.
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, Rate) (RateLimitResult, error) {
+	return RateLimitResult{}, errors.New("unavailable")
+}
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	type request struct {
+		ip        string
+		principal string
+	}
+
+	for _, tc := range []struct {
+		msg         string
+		store       RateLimitStore
+		rate        Rate
+		requests    []request
+		statusCodes []int
+		header      http.Header
+	}{
+		{
+			msg:         "limited by ip",
+			store:       NewMemoryRateLimitStore(),
+			rate:        Rate{Requests: 1, Per: time.Minute},
+			requests:    []request{{"192.0.2.1", "foo"}, {"192.0.2.1", "bar"}, {"192.0.2.2", "foo"}},
+			statusCodes: []int{http.StatusOK, http.StatusTooManyRequests, http.StatusOK},
+			header: http.Header{
+				"Ratelimit-Limit":     []string{"1"},
+				"Ratelimit-Remaining": []string{"0"},
+				"Ratelimit-Reset":     []string{"60"},
+			},
+		},
+		{
+			msg:         "failed authentication limited by ip",
+			store:       NewMemoryRateLimitStore(),
+			rate:        Rate{Requests: 1, Per: time.Minute},
+			requests:    []request{{"192.0.2.1", ""}, {"192.0.2.1", ""}},
+			statusCodes: []int{http.StatusUnauthorized, http.StatusTooManyRequests},
+			header: http.Header{
+				"Ratelimit-Limit":     []string{"1"},
+				"Ratelimit-Remaining": []string{"0"},
+				"Ratelimit-Reset":     []string{"60"},
+				"Retry-After":         []string{"60"},
+			},
+		},
+		{
+			msg:         "limited by principal",
+			store:       NewMemoryRateLimitStore(),
+			rate:        Rate{Requests: 1, Per: time.Minute, Key: RateLimitKeyPrincipal},
+			requests:    []request{{"192.0.2.1", "foo"}, {"192.0.2.2", "bar"}, {"192.0.2.3", "foo"}},
+			statusCodes: []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+		},
+		{
+			msg:         "failed authentication of principal rate limited by ip",
+			store:       NewMemoryRateLimitStore(),
+			rate:        Rate{Requests: 1, Per: time.Minute, Key: RateLimitKeyPrincipal},
+			requests:    []request{{"192.0.2.1", ""}, {"192.0.2.1", "foo"}},
+			statusCodes: []int{http.StatusUnauthorized, http.StatusTooManyRequests},
+		},
+		{
+			msg:         "no limit",
+			store:       NewMemoryRateLimitStore(),
+			requests:    []request{{"192.0.2.1", "foo"}, {"192.0.2.1", "foo"}},
+			statusCodes: []int{http.StatusOK, http.StatusOK},
+		},
+		{
+			msg:         "failing store",
+			store:       failingStore{},
+			rate:        Rate{Requests: 1, Per: time.Minute, Key: RateLimitKeyPrincipal},
+			requests:    []request{{"192.0.2.1", "foo"}, {"192.0.2.1", "foo"}},
+			statusCodes: []int{http.StatusOK, http.StatusOK},
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			router := gin.New()
+			router.Use(RateLimit(tc.store, "get_pet", tc.rate))
+			router.Use(func(c *gin.Context) {
+				uid := c.GetHeader("X-User")
+				if uid == "" {
+					c.AbortWithStatus(http.StatusUnauthorized)
+					return
+				}
+				setPrincipal(c, &Principal{User: User{UID: uid}, Scheme: "oauth2"})
+			})
+			router.Use(PrincipalRateLimit(tc.store, "get_pet", tc.rate))
+			router.GET("/", func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			var w *httptest.ResponseRecorder
+			for i, r := range tc.requests {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.RemoteAddr = r.ip + ":1234"
+				req.Header.Set("X-User", r.principal)
+
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				if w.Code != tc.statusCodes[i] {
+					t.Errorf("expected response code %d of request %d, got %d", tc.statusCodes[i], i, w.Code)
+				}
+			}
+
+			for name := range tc.header {
+				if value := w.Header().Get(name); value != tc.header.Get(name) {
+					t.Errorf("expected header %s '%s', got '%s'", name, tc.header.Get(name), value)
+				}
+			}
+		})
+	}
+}
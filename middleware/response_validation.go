@@ -0,0 +1,242 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag/stringutils"
+	"github.com/go-openapi/validate"
+	"github.com/mikkeloscar/gin-swagger/api"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	responseValidationContextKey = "response_validation"
+)
+
+// ResponseValidator validates responses against the responses declared for
+// the operations of a swagger spec.
+type ResponseValidator struct {
+	doc     *loads.Document
+	formats strfmt.Registry
+}
+
+// NewResponseValidator creates a ResponseValidator for the swagger spec
+// specJSON, typically the SwaggerJSON embedded in the generated server.
+func NewResponseValidator(specJSON json.RawMessage) (*ResponseValidator, error) {
+	doc, err := loads.Analyzed(specJSON, "")
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err = doc.Expanded()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResponseValidator{
+		doc:     doc,
+		formats: strfmt.Default,
+	}, nil
+}
+
+// Validate validates that the status code of the response is declared for
+// the operation and that the headers and body match the headers and schema
+// of the response. Headers not declared for the response aren't validated.
+func (v *ResponseValidator) Validate(operationID string, resp *api.Response) error {
+	_, _, operation, ok := v.doc.Analyzer.OperationForName(operationID)
+	if !ok {
+		return fmt.Errorf("operation '%s' is not defined in the spec", operationID)
+	}
+
+	if operation.Responses == nil {
+		return fmt.Errorf("no responses defined for operation '%s'", operationID)
+	}
+
+	response, ok := operation.Responses.StatusCodeResponses[resp.Code]
+	if !ok {
+		if operation.Responses.Default == nil {
+			return fmt.Errorf("status code %d is not defined for operation '%s'", resp.Code, operationID)
+		}
+		response = *operation.Responses.Default
+	}
+
+	err := v.validateHeaders(response.Headers, resp.Header)
+	if err != nil {
+		return err
+	}
+
+	if response.Schema == nil {
+		if !isNil(resp.Body) {
+			return fmt.Errorf("status code %d of operation '%s' defines no body", resp.Code, operationID)
+		}
+		return nil
+	}
+
+	// validate the JSON representation of the body, which is what the
+	// client receives.
+	data, err := json.Marshal(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var body interface{}
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		return err
+	}
+
+	result := validate.NewSchemaValidator(response.Schema, v.doc.Spec(), "body", v.formats).Validate(body)
+	return result.AsError()
+}
+
+// validateHeaders validates the values of the declared headers set in the
+// header.
+func (v *ResponseValidator) validateHeaders(headers map[string]spec.Header, header http.Header) error {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		values := header.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+
+		declared := headers[name]
+		data, err := headerValue(values, &declared.SimpleSchema)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("header %s is not of type %s: %w", name, declared.Type, err))
+			continue
+		}
+
+		result := validate.NewHeaderValidator(name, &declared, v.formats).Validate(data)
+		if err := result.AsError(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// headerValue converts the values of a header to the type of the schema.
+// Arrays are split by their collection format.
+func headerValue(values []string, schema *spec.SimpleSchema) (interface{}, error) {
+	switch schema.Type {
+	case "array":
+		parts := values
+		if schema.CollectionFormat != "multi" {
+			parts = stringutils.SplitByFormat(values[0], schema.CollectionFormat)
+		}
+
+		items := make([]interface{}, 0, len(parts))
+		for _, part := range parts {
+			if schema.Items == nil {
+				items = append(items, part)
+				continue
+			}
+
+			item, err := headerValue([]string{part}, &schema.Items.SimpleSchema)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case "integer":
+		return strconv.ParseInt(values[0], 10, 64)
+	case "number":
+		return strconv.ParseFloat(values[0], 64)
+	case "boolean":
+		return strconv.ParseBool(values[0])
+	}
+	return values[0], nil
+}
+
+// isNil returns true if the value is nil or a nil pointer, map or slice.
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+type responseValidation struct {
+	validator   *ResponseValidator
+	operationID string
+	strict      bool
+}
+
+// ValidateResponses is a middleware that enables validation of the responses
+// of the operation. The validation is done by ValidateResponse which must be
+// called by the endpoint handler of the operation.
+func ValidateResponses(validator *ResponseValidator, operationID string, strict bool) gin.HandlerFunc {
+	validation := &responseValidation{
+		validator:   validator,
+		operationID: operationID,
+		strict:      strict,
+	}
+
+	return func(c *gin.Context) {
+		c.Set(responseValidationContextKey, validation)
+		c.Next()
+	}
+}
+
+// ValidateResponse validates the response against the spec if response
+// validation is enabled for the route, see ValidateResponses.
+// Validation failures are logged. In strict mode a 500 Problem response is
+// returned instead of the invalid response.
+func ValidateResponse(c *gin.Context, resp *api.Response) *api.Response {
+	value, ok := c.Get(responseValidationContextKey)
+	if !ok {
+		return resp
+	}
+
+	validation, ok := value.(*responseValidation)
+	if !ok {
+		return resp
+	}
+
+	err := validation.validator.Validate(validation.operationID, resp)
+	if err == nil {
+		return resp
+	}
+
+	log.WithFields(log.Fields{
+		"operation": validation.operationID,
+		"status":    resp.Code,
+	}).Warnf("Response does not match the spec: %s", err)
+	_ = c.Error(err)
+
+	if !validation.strict {
+		return resp
+	}
+
+	c.Writer.Header().Set("Content-Type", "application/problem+json")
+	return &api.Response{
+		Code: http.StatusInternalServerError,
+		Body: api.Problem{
+			Title:  "Invalid response.",
+			Status: http.StatusInternalServerError,
+			Detail: err.Error(),
+		},
+	}
+}
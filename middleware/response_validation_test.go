@@ -0,0 +1,197 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mikkeloscar/gin-swagger/api"
+)
+
+var testSpec = []byte(`{
+  "swagger": "2.0",
+  "info": {"title": "test", "version": "0.0.1"},
+  "paths": {
+    "/persons/{name}": {
+      "get": {
+        "operationId": "getPerson",
+        "parameters": [
+          {"name": "name", "in": "path", "required": true, "type": "string"}
+        ],
+        "responses": {
+          "200": {
+            "description": "Person by name.",
+            "schema": {"$ref": "#/definitions/Person"},
+            "headers": {
+              "X-Request-Id": {"type": "string", "format": "uuid"},
+              "X-Version": {"type": "integer", "minimum": 1},
+              "X-Tags": {"type": "array", "items": {"type": "string", "enum": ["a", "b"]}}
+            }
+          },
+          "404": {
+            "description": "Not found."
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "Person": {
+      "type": "object",
+      "required": ["name"],
+      "properties": {
+        "name": {"type": "string"}
+      }
+    }
+  }
+}`)
+
+type person struct {
+	Name *string `json:"name,omitempty"`
+}
+
+func TestResponseValidator(t *testing.T) {
+	validator, err := NewResponseValidator(testSpec)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	name := "john"
+
+	for _, tc := range []struct {
+		msg      string
+		resp     *api.Response
+		expected bool
+	}{
+		{
+			msg:      "valid response body",
+			resp:     &api.Response{Code: http.StatusOK, Body: &person{Name: &name}},
+			expected: true,
+		},
+		{
+			msg:      "invalid response body",
+			resp:     &api.Response{Code: http.StatusOK, Body: &person{}},
+			expected: false,
+		},
+		{
+			msg:      "response without body",
+			resp:     &api.Response{Code: http.StatusNotFound, Body: (*person)(nil)},
+			expected: true,
+		},
+		{
+			msg:      "unexpected body",
+			resp:     &api.Response{Code: http.StatusNotFound, Body: &person{Name: &name}},
+			expected: false,
+		},
+		{
+			msg: "valid response headers",
+			resp: &api.Response{
+				Code: http.StatusOK,
+				Body: &person{Name: &name},
+				Header: http.Header{
+					"X-Request-Id": []string{"3d6fc5ba-26b1-4c36-8bb0-f1bce8a8a1d4"},
+					"X-Version":    []string{"2"},
+					"X-Tags":       []string{"a,b"},
+					"X-Undeclared": []string{"foo"},
+				},
+			},
+			expected: true,
+		},
+		{
+			msg: "invalid header format",
+			resp: &api.Response{
+				Code:   http.StatusOK,
+				Body:   &person{Name: &name},
+				Header: http.Header{"X-Request-Id": []string{"foo"}},
+			},
+			expected: false,
+		},
+		{
+			msg: "invalid header type",
+			resp: &api.Response{
+				Code:   http.StatusOK,
+				Body:   &person{Name: &name},
+				Header: http.Header{"X-Version": []string{"latest"}},
+			},
+			expected: false,
+		},
+		{
+			msg: "header below minimum",
+			resp: &api.Response{
+				Code:   http.StatusOK,
+				Body:   &person{Name: &name},
+				Header: http.Header{"X-Version": []string{"0"}},
+			},
+			expected: false,
+		},
+		{
+			msg: "array header item not in enum",
+			resp: &api.Response{
+				Code:   http.StatusOK,
+				Body:   &person{Name: &name},
+				Header: http.Header{"X-Tags": []string{"a,c"}},
+			},
+			expected: false,
+		},
+		{
+			msg:      "undeclared status code",
+			resp:     &api.Response{Code: http.StatusCreated, Body: &person{Name: &name}},
+			expected: false,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			err := validator.Validate("getPerson", tc.resp)
+			if tc.expected && err != nil {
+				t.Errorf("expected valid response, got %s", err)
+			}
+			if !tc.expected && err == nil {
+				t.Errorf("expected invalid response")
+			}
+		})
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	validator, err := NewResponseValidator(testSpec)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	for _, tc := range []struct {
+		msg        string
+		strict     bool
+		enabled    bool
+		statusCode int
+	}{
+		{
+			msg:        "validation disabled",
+			statusCode: http.StatusCreated,
+		},
+		{
+			msg:        "invalid response is logged",
+			enabled:    true,
+			statusCode: http.StatusCreated,
+		},
+		{
+			msg:        "invalid response is replaced in strict mode",
+			enabled:    true,
+			strict:     true,
+			statusCode: http.StatusInternalServerError,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			if tc.enabled {
+				ValidateResponses(validator, "getPerson", tc.strict)(ctx)
+			}
+
+			resp := ValidateResponse(ctx, &api.Response{Code: http.StatusCreated})
+			if resp.Code != tc.statusCode {
+				t.Errorf("expected response code %d, got %d", tc.statusCode, resp.Code)
+			}
+		})
+	}
+}
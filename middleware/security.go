@@ -0,0 +1,190 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	principalContextKey       = "principal"
+	securitySchemesContextKey = "security_schemes"
+)
+
+// Principal is the identity authenticated by a security scheme.
+type Principal struct {
+	User
+	// Scheme is the name of the security scheme which authenticated the
+	// principal.
+	Scheme string
+	// Scopes are the scopes required by the operation which are granted to
+	// the principal, mapped to their values.
+	Scopes map[string]interface{}
+}
+
+// SecurityScheme is a named Authenticator of a security definition.
+type SecurityScheme struct {
+	Name         string
+	Authenticate Authenticator
+}
+
+// SecurityRequirement is a set of security schemes which must all
+// authenticate a request. An empty requirement allows anonymous access.
+type SecurityRequirement []SecurityScheme
+
+// SecurityRequirements is a middleware that gives access if any of the
+// requirements is satisfied, following the semantics of the security of an
+// operation in the spec. The principal of the first scheme and the names of
+// all schemes of the satisfied requirement are set on the gin.Context, see
+// GetPrincipal and GetSecuritySchemes. Scopes granted by any of the schemes
+// are set on the gin.Context as well.
+//
+// If no requirement is satisfied the request is aborted with 403 if a
+// scheme authenticated the request but lacks a scope, otherwise with 401
+// and the challenges of all schemes.
+func SecurityRequirements(requirements ...SecurityRequirement) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var denied []*AuthError
+		for _, requirement := range requirements {
+			principals, err := requirement.authenticate(c)
+			if err != nil {
+				denied = append(denied, err)
+				continue
+			}
+
+			schemes := make([]string, len(principals))
+			// set in reverse so the principal of the first scheme takes
+			// precedence.
+			for i := len(principals) - 1; i >= 0; i-- {
+				schemes[i] = principals[i].Scheme
+				setPrincipal(c, principals[i])
+			}
+			c.Set(securitySchemesContextKey, schemes)
+
+			c.Next()
+			return
+		}
+
+		abortAuthError(c, combineAuthErrors(denied))
+	}
+}
+
+// authenticate authenticates the request with all schemes of the
+// requirement and returns their principals.
+func (r SecurityRequirement) authenticate(c *gin.Context) ([]*Principal, *AuthError) {
+	principals := make([]*Principal, 0, len(r))
+	for _, scheme := range r {
+		principal, err := scheme.Authenticate(c)
+		if err != nil {
+			authErr := toAuthError(err)
+			return nil, &AuthError{
+				Status:    authErr.Status,
+				Challenge: authErr.Challenge,
+				Detail:    fmt.Sprintf("%s: %s", scheme.Name, authErr.Detail),
+			}
+		}
+
+		if principal == nil {
+			principal = &Principal{}
+		}
+		principal.Scheme = scheme.Name
+		principals = append(principals, principal)
+	}
+	return principals, nil
+}
+
+// combineAuthErrors combines the errors of the unsatisfied requirements.
+// Forbidden errors take precedence as the request was authenticated, else
+// the challenges of all errors are combined.
+func combineAuthErrors(errs []*AuthError) *AuthError {
+	if len(errs) == 0 {
+		return unauthorized("", "no security requirement satisfied")
+	}
+
+	for _, err := range errs {
+		if err.Status == http.StatusForbidden {
+			return err
+		}
+	}
+
+	challenges := make([]string, 0, len(errs))
+	details := make([]string, 0, len(errs))
+	seen := make(map[string]struct{}, len(errs))
+	for _, err := range errs {
+		details = append(details, err.Detail)
+		if _, ok := seen[err.Challenge]; ok || err.Challenge == "" {
+			continue
+		}
+		seen[err.Challenge] = struct{}{}
+		challenges = append(challenges, err.Challenge)
+	}
+
+	return unauthorized(strings.Join(challenges, ", "), strings.Join(details, "; "))
+}
+
+// toAuthError converts an error returned by an Authenticator to an
+// AuthError. Errors of other types deny the request with 401.
+func toAuthError(err error) *AuthError {
+	var authErr *AuthError
+	if errors.As(err, &authErr) {
+		return authErr
+	}
+	return unauthorized("", err.Error())
+}
+
+// setPrincipal sets the principal, its uid, realm and scopes on the
+// gin.Context.
+func setPrincipal(c *gin.Context, principal *Principal) {
+	if principal == nil {
+		return
+	}
+
+	c.Set(principalContextKey, *principal)
+	for scope, value := range principal.Scopes {
+		c.Set(scope, value)
+	}
+	// scopes such as uid must not override the user.
+	setUser(c, principal.User)
+}
+
+// GetPrincipal gets the principal authenticated by the security
+// requirements of the operation from a gin context.
+func GetPrincipal(c *gin.Context) (Principal, bool) {
+	principal, ok := c.Get(principalContextKey)
+	if !ok {
+		return Principal{}, false
+	}
+
+	p, ok := principal.(Principal)
+	return p, ok
+}
+
+// principalKey returns a key identifying the principal of the request, or
+// an empty string if it has none.
+func principalKey(c *gin.Context) string {
+	principal, ok := GetPrincipal(c)
+	if !ok {
+		return ""
+	}
+	return "principal:" + principal.Scheme + ":" + principal.Realm + ":" + principal.UID
+}
+
+// clientKey returns a key identifying the client of the request by its
+// principal, or by its IP address if it has none.
+func clientKey(c *gin.Context) string {
+	if principal := principalKey(c); principal != "" {
+		return principal
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// GetSecuritySchemes gets the names of the security schemes of the
+// requirement which authenticated the request from a gin context.
+func GetSecuritySchemes(c *gin.Context) []string {
+	schemes, _ := c.Get(securitySchemesContextKey)
+	names, _ := schemes.([]string)
+	return names
+}
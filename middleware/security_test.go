@@ -0,0 +1,187 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSecurityRequirements(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	apiKey := SecurityScheme{Name: "ApiKey", Authenticate: APIKeyAuthenticator("X-API-Key", "header", validateKey)}
+	basic := SecurityScheme{Name: "Basic", Authenticate: BasicAuthenticator("Example", validateCredentials)}
+	scoped := SecurityScheme{Name: "OAuth2", Authenticate: func(c *gin.Context) (*Principal, error) {
+		if c.GetHeader("X-Scope") != "read" {
+			return nil, &AuthError{Status: http.StatusForbidden, Detail: "missing scope"}
+		}
+		return &Principal{Scopes: map[string]interface{}{"read": true}}, nil
+	}}
+
+	for _, tc := range []struct {
+		msg          string
+		requirements []SecurityRequirement
+		setup        func(req *http.Request)
+		statusCode   int
+		challenge    string
+		schemes      []string
+		uid          string
+	}{
+		{
+			msg:          "first alternative satisfied",
+			requirements: []SecurityRequirement{{apiKey}, {basic}},
+			setup:        func(req *http.Request) { req.Header.Set("X-API-Key", "secret") },
+			statusCode:   http.StatusOK,
+			schemes:      []string{"ApiKey"},
+			uid:          "johndoe",
+		},
+		{
+			msg:          "no alternative satisfied",
+			requirements: []SecurityRequirement{{apiKey}, {basic}},
+			setup:        func(req *http.Request) { req.SetBasicAuth("janedoe", "secret") },
+			statusCode:   http.StatusUnauthorized,
+			challenge:    `APIKey in="header", name="X-API-Key", Basic realm="Example", charset="UTF-8"`,
+		},
+		{
+			msg:          "second alternative satisfied",
+			requirements: []SecurityRequirement{{apiKey}, {basic}},
+			setup:        func(req *http.Request) { req.SetBasicAuth("johndoe", "secret") },
+			statusCode:   http.StatusOK,
+			schemes:      []string{"Basic"},
+			uid:          "johndoe",
+		},
+		{
+			msg:          "combined schemes all satisfied",
+			requirements: []SecurityRequirement{{apiKey, scoped}},
+			setup: func(req *http.Request) {
+				req.Header.Set("X-API-Key", "secret")
+				req.Header.Set("X-Scope", "read")
+			},
+			statusCode: http.StatusOK,
+			schemes:    []string{"ApiKey", "OAuth2"},
+			uid:        "johndoe",
+		},
+		{
+			msg:          "combined schemes partially satisfied",
+			requirements: []SecurityRequirement{{apiKey, basic}},
+			setup:        func(req *http.Request) { req.Header.Set("X-API-Key", "secret") },
+			statusCode:   http.StatusUnauthorized,
+			challenge:    `Basic realm="Example", charset="UTF-8"`,
+		},
+		{
+			msg:          "missing scope is forbidden",
+			requirements: []SecurityRequirement{{scoped}, {basic}},
+			statusCode:   http.StatusForbidden,
+		},
+		{
+			msg:          "empty requirement allows anonymous access",
+			requirements: []SecurityRequirement{{apiKey}, {}},
+			statusCode:   http.StatusOK,
+			schemes:      []string{},
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/", SecurityRequirements(tc.requirements...), func(c *gin.Context) {
+				if schemes := GetSecuritySchemes(c); !reflect.DeepEqual(schemes, tc.schemes) {
+					t.Errorf("expected schemes %v, got %v", tc.schemes, schemes)
+				}
+
+				principal, _ := GetPrincipal(c)
+				if principal.UID != tc.uid {
+					t.Errorf("expected principal uid '%s', got '%s'", tc.uid, principal.UID)
+				}
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest("GET", "/", nil)
+			if tc.setup != nil {
+				tc.setup(req)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("expected response code %d, got %d", tc.statusCode, w.Code)
+			}
+
+			if challenge := w.Header().Get("WWW-Authenticate"); challenge != tc.challenge {
+				t.Errorf("expected challenge '%s', got '%s'", tc.challenge, challenge)
+			}
+		})
+	}
+}
+
+func TestTokenInfoAuthenticator(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	tokenInfo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("access_token") != "token" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"error":             "invalid_request",
+				"error_description": "Access Token not valid",
+			})
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"uid":   "johndoe",
+			"realm": "/employees",
+			"scope": []string{"uid", "read"},
+			"read":  true,
+		})
+	}))
+	defer tokenInfo.Close()
+
+	for _, tc := range []struct {
+		msg        string
+		scopes     []string
+		token      string
+		statusCode int
+	}{
+		{
+			msg:        "valid token with scopes",
+			scopes:     []string{"uid", "read"},
+			token:      "token",
+			statusCode: http.StatusOK,
+		},
+		{
+			msg:        "invalid token",
+			token:      "invalid",
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			msg:        "missing scope",
+			scopes:     []string{"write"},
+			token:      "token",
+			statusCode: http.StatusForbidden,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/", authenticate(TokenInfoAuthenticator(tokenInfo.URL, tc.scopes...)), func(c *gin.Context) {
+				user := GetUser(c)
+				if user.UID != "johndoe" || user.Realm != "/employees" {
+					t.Errorf("expected user to be set, got %#v", user)
+				}
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest("GET", "/", nil)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Errorf("expected response code %d, got %d", tc.statusCode, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-openapi/loads"
+	"github.com/mikkeloscar/gin-swagger/mock"
+)
+
+const defaultMockAddress = ":8080"
+
+// runMock serves mock responses for the operations of the spec at specPath
+// on address.
+func runMock(specPath, address string) error {
+	spec, err := loadSpec(specPath, true)
+	if err != nil {
+		return err
+	}
+	defer spec.Cleanup()
+
+	doc, err := loads.Spec(spec.Path)
+	if err != nil {
+		return err
+	}
+
+	gin.SetMode(gin.ReleaseMode)
+	handler, err := mock.NewHandler(doc)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Serving mock of '%s' on address %s", doc.Spec().Info.Title, address)
+	return handler.Run(address)
+}
@@ -0,0 +1,175 @@
+package mock
+
+import (
+	"fmt"
+	"mime"
+	"sort"
+
+	"github.com/go-openapi/spec"
+)
+
+// maxDepth limits the nesting of synthesized examples, e.g. for recursive
+// schemas.
+const maxDepth = 10
+
+// exampleExtension is the vendor extension holding examples where the spec
+// doesn't allow an example.
+const exampleExtension = "x-example"
+
+// formatExamples are the examples of string formats.
+var formatExamples = map[string]string{
+	"date":      "1970-01-01",
+	"date-time": "1970-01-01T00:00:00Z",
+	"uuid":      "00000000-0000-0000-0000-000000000000",
+	"email":     "user@example.org",
+	"hostname":  "example.org",
+	"uri":       "https://example.org",
+	"url":       "https://example.org",
+	"ipv4":      "127.0.0.1",
+	"ipv6":      "::1",
+	"byte":      "c3RyaW5n",
+	"duration":  "1s",
+}
+
+// responseExample returns the example of the response for the media type,
+// taken from the examples of the response or synthesized from its schema.
+func responseExample(swagger *spec.Swagger, response *spec.Response, mediaType string) interface{} {
+	if example, ok := response.Examples[mediaType]; ok {
+		return example
+	}
+
+	// the examples are keyed by media types without parameters.
+	if base, _, err := mime.ParseMediaType(mediaType); err == nil {
+		if example, ok := response.Examples[base]; ok {
+			return example
+		}
+	}
+
+	if example, ok := response.Extensions[exampleExtension]; ok {
+		return example
+	}
+
+	if response.Schema == nil {
+		return nil
+	}
+
+	return schemaExample(swagger, response.Schema, 0)
+}
+
+// schemaExample returns the example of the schema, taken from its example,
+// x-example, first enum value or default, or synthesized from its type.
+func schemaExample(swagger *spec.Swagger, schema *spec.Schema, depth int) interface{} {
+	if schema == nil || depth > maxDepth {
+		return nil
+	}
+
+	if schema.Ref.String() != "" {
+		resolved, err := spec.ResolveRef(swagger, &schema.Ref)
+		if err != nil {
+			return nil
+		}
+		return schemaExample(swagger, resolved, depth+1)
+	}
+
+	switch {
+	case schema.Example != nil:
+		return schema.Example
+	case schema.Extensions[exampleExtension] != nil:
+		return schema.Extensions[exampleExtension]
+	case len(schema.Enum) > 0:
+		return schema.Enum[0]
+	case schema.Default != nil:
+		return schema.Default
+	}
+
+	if len(schema.AllOf) > 0 {
+		object := make(map[string]interface{})
+		for i := range schema.AllOf {
+			if properties, ok := schemaExample(swagger, &schema.AllOf[i], depth+1).(map[string]interface{}); ok {
+				for name, value := range properties {
+					object[name] = value
+				}
+			}
+		}
+		return object
+	}
+
+	switch {
+	case schema.Type.Contains("array"):
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return []interface{}{}
+		}
+
+		n := 1
+		if schema.MinItems != nil && *schema.MinItems > 1 {
+			n = int(*schema.MinItems)
+		}
+
+		items := make([]interface{}, 0, n)
+		for i := 0; i < n; i++ {
+			items = append(items, schemaExample(swagger, schema.Items.Schema, depth+1))
+		}
+		return items
+	case schema.Type.Contains("string"):
+		return stringExample(schema.Format, schema.MinLength)
+	case schema.Type.Contains("integer"):
+		if schema.Minimum != nil {
+			return int64(*schema.Minimum)
+		}
+		return 0
+	case schema.Type.Contains("number"):
+		if schema.Minimum != nil {
+			return *schema.Minimum
+		}
+		return 0.0
+	case schema.Type.Contains("boolean"):
+		return false
+	case schema.Type.Contains("object") || len(schema.Properties) > 0:
+		names := make([]string, 0, len(schema.Properties))
+		for name := range schema.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		object := make(map[string]interface{}, len(names))
+		for _, name := range names {
+			property := schema.Properties[name]
+			object[name] = schemaExample(swagger, &property, depth+1)
+		}
+		return object
+	}
+
+	return nil
+}
+
+// stringExample returns an example string of the format with at least
+// minLength characters.
+func stringExample(format string, minLength *int64) string {
+	example, ok := formatExamples[format]
+	if !ok {
+		example = "string"
+	}
+
+	if minLength != nil {
+		for int64(len(example)) < *minLength {
+			example += example
+		}
+	}
+	return example
+}
+
+// headerExample returns the example of a response header, or an empty
+// string if it has none.
+func headerExample(header spec.Header) string {
+	switch {
+	case header.Example != nil:
+		return fmt.Sprint(header.Example)
+	case header.Extensions[exampleExtension] != nil:
+		return fmt.Sprint(header.Extensions[exampleExtension])
+	case len(header.Enum) > 0:
+		return fmt.Sprint(header.Enum[0])
+	case header.Default != nil:
+		return fmt.Sprint(header.Default)
+	}
+	return ""
+}
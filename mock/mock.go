@@ -0,0 +1,226 @@
+// Package mock serves the operations of a spec with responses synthesized
+// from the spec, for use before the service is implemented.
+package mock
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-openapi/analysis"
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/runtime"
+	runtimemiddleware "github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/strfmt"
+	"github.com/mikkeloscar/gin-swagger/api"
+	"github.com/mikkeloscar/gin-swagger/middleware"
+)
+
+// StatusHeader is the request header selecting the status code of the
+// response among the ones declared for the operation.
+const StatusHeader = "X-Mock-Status"
+
+// NewHandler creates a gin.Engine serving every operation of the spec. The
+// parameters of the requests are validated and the responses are taken
+// from the examples of the spec or synthesized from the response schemas.
+func NewHandler(doc *loads.Document) (*gin.Engine, error) {
+	expanded, err := doc.Expanded()
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand spec: %w", err)
+	}
+
+	swagger := expanded.Spec()
+	analyzed := analysis.New(swagger)
+
+	engine := gin.New()
+	engine.Use(gin.Recovery())
+
+	basePath := swagger.BasePath
+	if basePath == "" {
+		basePath = "/"
+	}
+
+	methods := make([]string, 0, len(analyzed.Operations()))
+	for method := range analyzed.Operations() {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	for _, method := range methods {
+		paths := make([]string, 0, len(analyzed.Operations()[method]))
+		for p := range analyzed.Operations()[method] {
+			paths = append(paths, p)
+		}
+		sort.Strings(paths)
+
+		for _, p := range paths {
+			op := analyzed.Operations()[method][p]
+			produces := analyzed.ProducesFor(op)
+			if len(produces) == 0 {
+				produces = []string{runtime.JSONMime}
+			}
+
+			handlers := []gin.HandlerFunc{middleware.Produces(produces...)}
+
+			params := analyzed.ParamsFor(method, p)
+			if method != http.MethodGet && hasBodyParam(params) {
+				handlers = append(handlers, middleware.ContentTypes(analyzed.ConsumesFor(op)...))
+			}
+
+			handlers = append(handlers, operationHandler(swagger, op, params))
+
+			err := handle(engine, method, path.Join(basePath, ginizePath(p)), handlers)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return engine, nil
+}
+
+// handle adds the route to the engine. gin panics on conflicting routes,
+// which is returned as an error.
+func handle(engine *gin.Engine, method, route string, handlers []gin.HandlerFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to add route %s %s: %v", method, route, r)
+		}
+	}()
+
+	engine.Handle(method, route, handlers...)
+	return nil
+}
+
+// operationHandler validates the parameters of the request and responds
+// with the response selected by the StatusHeader.
+func operationHandler(swagger *spec.Swagger, op *spec.Operation, params map[string]spec.Parameter) gin.HandlerFunc {
+	binder := runtimemiddleware.NewUntypedRequestBinder(params, swagger, strfmt.Default)
+
+	return func(c *gin.Context) {
+		routeParams := make(runtimemiddleware.RouteParams, 0, len(c.Params))
+		for _, param := range c.Params {
+			routeParams = append(routeParams, runtimemiddleware.RouteParam{Name: param.Key, Value: param.Value})
+		}
+
+		data := make(map[string]interface{})
+		err := binder.Bind(c.Request, routeParams, consumer(c), &data)
+		if err != nil {
+			problem := middleware.ValidationProblem(err)
+			_ = c.Error(err).SetType(gin.ErrorTypePublic)
+			middleware.WriteResponse(c, &api.Response{Code: problem.Status, Body: problem})
+			return
+		}
+
+		code, response, ok := selectResponse(op, c.GetHeader(StatusHeader))
+		if !ok {
+			middleware.WriteResponse(c, &api.Response{
+				Code: http.StatusBadRequest,
+				Body: api.Problem{
+					Title:  "Bad Request.",
+					Status: http.StatusBadRequest,
+					Detail: fmt.Sprintf("the status '%s' of the %s header is not declared for the operation", c.GetHeader(StatusHeader), StatusHeader),
+				},
+			})
+			return
+		}
+
+		for name, header := range response.Headers {
+			if value := headerExample(header); value != "" {
+				c.Header(name, value)
+			}
+		}
+
+		middleware.WriteResponse(c, &api.Response{
+			Code: code,
+			Body: responseExample(swagger, response, middleware.ResponseMediaType(c)),
+		})
+	}
+}
+
+// consumer returns a runtime.Consumer decoding the request body with the
+// api.Codec of its Content-Type.
+func consumer(c *gin.Context) runtime.Consumer {
+	return runtime.ConsumerFunc(func(r io.Reader, v interface{}) error {
+		mediaType := c.ContentType()
+		if mediaType == "" {
+			mediaType = runtime.JSONMime
+		}
+
+		codec, ok := api.LookupCodec(mediaType)
+		if !ok {
+			return fmt.Errorf("unsupported media type '%s'", mediaType)
+		}
+		return codec.Decode(r, v)
+	})
+}
+
+// selectResponse returns the response of the operation for the status. If
+// status is empty the first success response is selected, else the
+// default response with 200.
+func selectResponse(op *spec.Operation, status string) (int, *spec.Response, bool) {
+	if op.Responses == nil {
+		return http.StatusOK, &spec.Response{}, status == ""
+	}
+
+	if status != "" {
+		code, err := strconv.Atoi(status)
+		if err != nil || code < 100 || code > 599 {
+			return 0, nil, false
+		}
+
+		if response, ok := op.Responses.StatusCodeResponses[code]; ok {
+			return code, &response, true
+		}
+
+		if op.Responses.Default != nil {
+			return code, op.Responses.Default, true
+		}
+		return 0, nil, false
+	}
+
+	codes := make([]int, 0, len(op.Responses.StatusCodeResponses))
+	for code := range op.Responses.StatusCodeResponses {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+
+	for _, code := range codes {
+		if code >= 200 && code < 300 {
+			response := op.Responses.StatusCodeResponses[code]
+			return code, &response, true
+		}
+	}
+
+	if op.Responses.Default != nil {
+		return http.StatusOK, op.Responses.Default, true
+	}
+
+	if len(codes) > 0 {
+		response := op.Responses.StatusCodeResponses[codes[0]]
+		return codes[0], &response, true
+	}
+
+	return http.StatusOK, &spec.Response{}, true
+}
+
+// hasBodyParam returns true if any of the params is a body param.
+func hasBodyParam(params map[string]spec.Parameter) bool {
+	for _, param := range params {
+		if param.In == "body" {
+			return true
+		}
+	}
+	return false
+}
+
+// ginizePath converts the path params of a spec path to gin path params.
+func ginizePath(p string) string {
+	return strings.NewReplacer("{", ":", "}", "").Replace(p)
+}
@@ -0,0 +1,222 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-openapi/loads"
+)
+
+const petStore = `{
+  "swagger": "2.0",
+  "info": {"title": "Pet Store", "version": "1.0.0"},
+  "basePath": "/api",
+  "produces": ["application/json"],
+  "consumes": ["application/json"],
+  "paths": {
+    "/pets/{name}": {
+      "get": {
+        "operationId": "getPet",
+        "parameters": [
+          {"name": "name", "in": "path", "required": true, "type": "string", "pattern": "^[a-z]+$"},
+          {"name": "limit", "in": "query", "type": "integer", "maximum": 10}
+        ],
+        "responses": {
+          "200": {
+            "description": "The pet.",
+            "headers": {"X-Request-Id": {"type": "string", "x-example": "abc"}},
+            "schema": {"$ref": "#/definitions/Pet"}
+          },
+          "404": {
+            "description": "Not found.",
+            "examples": {"application/json": {"title": "Not Found."}}
+          }
+        }
+      },
+      "patch": {
+        "operationId": "updatePet",
+        "consumes": ["application/merge-patch+json"],
+        "produces": ["application/vnd.api+json"],
+        "parameters": [
+          {"name": "name", "in": "path", "required": true, "type": "string"},
+          {"name": "pet", "in": "body", "required": true, "schema": {"type": "object"}}
+        ],
+        "responses": {
+          "200": {"description": "The pet.", "schema": {"$ref": "#/definitions/Pet"}}
+        }
+      }
+    },
+    "/pets": {
+      "post": {
+        "operationId": "createPet",
+        "parameters": [
+          {"name": "pet", "in": "body", "required": true, "schema": {"$ref": "#/definitions/Pet"}}
+        ],
+        "responses": {
+          "201": {"description": "Created."},
+          "default": {"description": "Error."}
+        }
+      }
+    }
+  },
+  "definitions": {
+    "Pet": {
+      "type": "object",
+      "required": ["name"],
+      "properties": {
+        "name": {"type": "string", "example": "rex"},
+        "born": {"type": "string", "format": "date"},
+        "tags": {"type": "array", "items": {"type": "string", "enum": ["good"]}},
+        "age": {"type": "integer", "minimum": 1}
+      }
+    }
+  }
+}`
+
+func TestNewHandler(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	doc, err := loads.Analyzed(json.RawMessage(petStore), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler, err := NewHandler(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		msg        string
+		method     string
+		path       string
+		body       string
+		mediaType  string
+		status     string
+		statusCode int
+		header     http.Header
+		expected   interface{}
+	}{
+		{
+			msg:        "synthesized success response",
+			method:     "GET",
+			path:       "/api/pets/rex",
+			statusCode: http.StatusOK,
+			header:     http.Header{"X-Request-Id": []string{"abc"}},
+			expected: map[string]interface{}{
+				"name": "rex",
+				"born": "1970-01-01",
+				"tags": []interface{}{"good"},
+				"age":  float64(1),
+			},
+		},
+		{
+			msg:        "example of the selected status",
+			method:     "GET",
+			path:       "/api/pets/rex",
+			status:     "404",
+			statusCode: http.StatusNotFound,
+			expected:   map[string]interface{}{"title": "Not Found."},
+		},
+		{
+			msg:        "undeclared status",
+			method:     "GET",
+			path:       "/api/pets/rex",
+			status:     "418",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			msg:        "invalid path param",
+			method:     "GET",
+			path:       "/api/pets/Rex",
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			msg:        "invalid query param",
+			method:     "GET",
+			path:       "/api/pets/rex?limit=11",
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			msg:        "valid body",
+			method:     "POST",
+			path:       "/api/pets",
+			body:       `{"name": "rex"}`,
+			statusCode: http.StatusCreated,
+		},
+		{
+			msg:        "default response of the selected status",
+			method:     "POST",
+			path:       "/api/pets",
+			body:       `{"name": "rex"}`,
+			status:     "503",
+			statusCode: http.StatusServiceUnavailable,
+		},
+		{
+			msg:        "invalid status with default response",
+			method:     "POST",
+			path:       "/api/pets",
+			body:       `{"name": "rex"}`,
+			status:     "42",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			msg:        "json suffix media types",
+			method:     "PATCH",
+			path:       "/api/pets/rex",
+			body:       `{"age": 2}`,
+			mediaType:  "application/merge-patch+json",
+			statusCode: http.StatusOK,
+			header:     http.Header{"Content-Type": []string{"application/vnd.api+json; charset=utf-8"}},
+		},
+		{
+			msg:        "body missing required property",
+			method:     "POST",
+			path:       "/api/pets",
+			body:       `{"age": 2}`,
+			statusCode: http.StatusUnprocessableEntity,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			if tc.mediaType != "" {
+				req.Header.Set("Content-Type", tc.mediaType)
+			} else if tc.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			if tc.status != "" {
+				req.Header.Set(StatusHeader, tc.status)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tc.statusCode {
+				t.Fatalf("expected response code %d, got %d: %s", tc.statusCode, w.Code, w.Body.String())
+			}
+
+			for name := range tc.header {
+				if value := w.Header().Get(name); value != tc.header.Get(name) {
+					t.Errorf("expected header %s '%s', got '%s'", name, tc.header.Get(name), value)
+				}
+			}
+
+			if tc.expected != nil {
+				var body interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &body)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !reflect.DeepEqual(body, tc.expected) {
+					t.Errorf("expected body %v, got %v", tc.expected, body)
+				}
+			}
+		})
+	}
+}
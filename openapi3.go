@@ -0,0 +1,339 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	yaml "go.yaml.in/yaml/v3"
+)
+
+const (
+	// openAPIVersionExtension is set on the info object of a Swagger 2.0
+	// document converted from an OpenAPI 3.x document. The templates use it
+	// to find the version of the original spec.
+	openAPIVersionExtension = "x-openapi-version"
+	openAPISpecFileName     = "openapi_spec.go"
+	// httpSchemeExtension holds the original scheme of an OpenAPI 3.x http
+	// security scheme which was converted to an apiKey scheme.
+	httpSchemeExtension = "x-http-scheme"
+)
+
+// specDocument is the spec passed to the generator. For OpenAPI 3.x specs it
+// points to a converted Swagger 2.0 copy of the original document.
+type specDocument struct {
+	// Path is the path of the Swagger 2.0 document used for generation.
+	Path string
+	// Version is the version of the original spec e.g. '2.0' or '3.0.3'.
+	Version string
+	// OpenAPIJSON is the JSON encoding of the original OpenAPI 3.x
+	// document. It's empty for Swagger 2.0 specs.
+	OpenAPIJSON []byte
+	tmpDir      string
+}
+
+// IsOpenAPI3 returns true if the original spec is an OpenAPI 3.x document.
+func (s *specDocument) IsOpenAPI3() bool {
+	return strings.HasPrefix(s.Version, "3.")
+}
+
+// Cleanup removes any temporary files created when loading the spec.
+func (s *specDocument) Cleanup() {
+	if s.tmpDir != "" {
+		_ = os.RemoveAll(s.tmpDir)
+	}
+}
+
+// loadSpec loads the spec at specPath. Swagger 2.0 specs are passed through
+// as is, while OpenAPI 3.x specs are converted to a temporary Swagger 2.0
+// document which go-swagger can generate from.
+func loadSpec(specPath string, validateSpec bool) (*specDocument, error) {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var header struct {
+		Swagger string `yaml:"swagger"`
+		OpenAPI string `yaml:"openapi"`
+	}
+	if err := yaml.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to parse spec %s: %w", specPath, err)
+	}
+
+	switch {
+	case header.OpenAPI != "":
+		if !strings.HasPrefix(header.OpenAPI, "3.") {
+			return nil, fmt.Errorf("unsupported OpenAPI version %q", header.OpenAPI)
+		}
+	case header.Swagger != "":
+		return &specDocument{Path: specPath, Version: header.Swagger}, nil
+	default:
+		return nil, fmt.Errorf("spec %s has neither a 'swagger' nor an 'openapi' version field", specPath)
+	}
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	doc3, err := loader.LoadFromFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI spec %s: %w", specPath, err)
+	}
+
+	ctx := context.Background()
+	if validateSpec {
+		if err := doc3.Validate(ctx); err != nil {
+			return nil, fmt.Errorf("invalid OpenAPI spec %s: %w", specPath, err)
+		}
+	}
+
+	// external refs can't be resolved relative to the temporary Swagger
+	// document so they are moved into the components section first.
+	doc3.InternalizeRefs(ctx, nil)
+
+	openAPIJSON, err := json.MarshalIndent(doc3, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	doc2, err := convertOpenAPI3(doc3)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert OpenAPI spec %s: %w", specPath, err)
+	}
+
+	swaggerJSON, err := json.MarshalIndent(doc2, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "gin-swagger-spec-*")
+	if err != nil {
+		return nil, err
+	}
+
+	swaggerPath := filepath.Join(tmpDir, "swagger.json")
+	if err := os.WriteFile(swaggerPath, swaggerJSON, 0o644); err != nil {
+		_ = os.RemoveAll(tmpDir)
+		return nil, err
+	}
+
+	return &specDocument{
+		Path:        swaggerPath,
+		Version:     doc3.OpenAPI,
+		OpenAPIJSON: openAPIJSON,
+		tmpDir:      tmpDir,
+	}, nil
+}
+
+// convertOpenAPI3 converts an OpenAPI 3.x document to Swagger 2.0.
+//
+// Constructs which can't be expressed in Swagger 2.0 are mapped to the
+// closest equivalent:
+//
+//   - oneOf/anyOf schemas become untyped schemas (interface{} in Go), the
+//     alternatives are kept in x-oneOf/x-anyOf for documentation.
+//   - http security schemes other than basic (e.g. bearer) become apiKey
+//     schemes on the Authorization header, the original scheme is kept in
+//     x-http-scheme.
+//   - requestBody with several media types becomes a single body parameter
+//     and the media types are listed in the operation's consumes.
+func convertOpenAPI3(doc3 *openapi3.T) (*openapi2.T, error) {
+	if doc3.Components == nil {
+		doc3.Components = &openapi3.Components{}
+	}
+
+	annotateCompositeSchemas(doc3)
+
+	doc2, err := openapi2conv.FromV3(doc3)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, scheme := range doc3.Components.SecuritySchemes {
+		if scheme.Value == nil || scheme.Value.Type != "http" {
+			continue
+		}
+		def, ok := doc2.SecurityDefinitions[name]
+		if !ok || def.Type != "apiKey" {
+			continue
+		}
+		if def.Extensions == nil {
+			def.Extensions = make(map[string]any)
+		}
+		def.Extensions[httpSchemeExtension] = strings.ToLower(scheme.Value.Scheme)
+	}
+
+	if doc2.Info.Extensions == nil {
+		doc2.Info.Extensions = make(map[string]any)
+	}
+	doc2.Info.Extensions[openAPIVersionExtension] = doc3.OpenAPI
+
+	return doc2, nil
+}
+
+// annotateCompositeSchemas records the oneOf/anyOf alternatives of all
+// schemas in the document as x-oneOf/x-anyOf extensions, as they are
+// otherwise dropped when converting to Swagger 2.0.
+func annotateCompositeSchemas(doc3 *openapi3.T) {
+	visited := make(map[*openapi3.Schema]struct{})
+
+	var visit func(ref *openapi3.SchemaRef)
+	visit = func(ref *openapi3.SchemaRef) {
+		if ref == nil || ref.Value == nil {
+			return
+		}
+		schema := ref.Value
+		if _, ok := visited[schema]; ok {
+			return
+		}
+		visited[schema] = struct{}{}
+
+		for _, composite := range []struct {
+			extension string
+			refs      openapi3.SchemaRefs
+		}{
+			{"x-oneOf", schema.OneOf},
+			{"x-anyOf", schema.AnyOf},
+		} {
+			if len(composite.refs) == 0 {
+				continue
+			}
+			alternatives := make([]*openapi2.SchemaRef, 0, len(composite.refs))
+			for _, alternative := range composite.refs {
+				visit(alternative)
+				converted, _ := openapi2conv.FromV3SchemaRef(alternative, doc3.Components)
+				if converted != nil {
+					alternatives = append(alternatives, converted)
+				}
+			}
+			if schema.Extensions == nil {
+				schema.Extensions = make(map[string]any)
+			}
+			schema.Extensions[composite.extension] = alternatives
+		}
+
+		for _, property := range schema.Properties {
+			visit(property)
+		}
+		for _, sub := range schema.AllOf {
+			visit(sub)
+		}
+		visit(schema.Items)
+		visit(schema.Not)
+		visit(schema.AdditionalProperties.Schema)
+	}
+
+	visitContent := func(content openapi3.Content) {
+		for _, mediaType := range content {
+			visit(mediaType.Schema)
+		}
+	}
+
+	visitParameters := func(parameters openapi3.Parameters) {
+		for _, parameter := range parameters {
+			if parameter.Value != nil {
+				visit(parameter.Value.Schema)
+				visitContent(parameter.Value.Content)
+			}
+		}
+	}
+
+	for _, schema := range doc3.Components.Schemas {
+		visit(schema)
+	}
+	for _, requestBody := range doc3.Components.RequestBodies {
+		if requestBody.Value != nil {
+			visitContent(requestBody.Value.Content)
+		}
+	}
+	for _, response := range doc3.Components.Responses {
+		if response.Value != nil {
+			visitContent(response.Value.Content)
+		}
+	}
+	for _, parameter := range doc3.Components.Parameters {
+		visitParameters(openapi3.Parameters{parameter})
+	}
+
+	if doc3.Paths == nil {
+		return
+	}
+
+	for _, pathItem := range doc3.Paths.Map() {
+		visitParameters(pathItem.Parameters)
+		for _, operation := range pathItem.Operations() {
+			visitParameters(operation.Parameters)
+			if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+				visitContent(operation.RequestBody.Value.Content)
+			}
+			if operation.Responses == nil {
+				continue
+			}
+			for _, response := range operation.Responses.Map() {
+				if response.Value != nil {
+					visitContent(response.Value.Content)
+				}
+			}
+		}
+	}
+}
+
+var openAPISpecTemplate = template.Must(template.New("openapi_spec").Parse(`// Code generated by gin-swagger; DO NOT EDIT.
+
+{{ with .Copyright }}{{ . }}
+
+{{ end }}package {{ .Package }}
+
+import (
+	"encoding/json"
+)
+
+var (
+	// OpenAPIJSON embedded version of the OpenAPI {{ .Version }} document used at
+	// generation time. SwaggerJSON holds the Swagger 2.0 conversion of it.
+	OpenAPIJSON json.RawMessage
+)
+
+func init() {
+	OpenAPIJSON = json.RawMessage([]byte({{ .JSON }}))
+}
+`))
+
+// writeOpenAPISpec writes the original OpenAPI 3.x document as an embedded
+// spec next to the generated server.
+func writeOpenAPISpec(spec *specDocument, target, serverPackage, copyright string) error {
+	if copyright != "" {
+		copyright = "// " + strings.ReplaceAll(copyright, "\n", "\n// ")
+	}
+
+	var buf bytes.Buffer
+	err := openAPISpecTemplate.Execute(&buf, struct {
+		Copyright string
+		Package   string
+		Version   string
+		JSON      string
+	}{
+		Copyright: copyright,
+		Package:   filepath.Base(serverPackage),
+		Version:   spec.Version,
+		JSON:      "`" + strings.ReplaceAll(string(spec.OpenAPIJSON), "`", "` + \"`\" + `") + "`",
+	})
+	if err != nil {
+		return err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(target, serverPackage, openAPISpecFileName), src, 0o644)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi2"
+)
+
+func TestLoadSpecSwagger2(t *testing.T) {
+	spec, err := loadSpec("example/swagger.yaml", true)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+	defer spec.Cleanup()
+
+	if spec.IsOpenAPI3() {
+		t.Errorf("expected Swagger 2.0 spec, got version %s", spec.Version)
+	}
+
+	if spec.Path != "example/swagger.yaml" {
+		t.Errorf("expected spec path to be passed through, got %s", spec.Path)
+	}
+}
+
+func TestLoadSpecOpenAPI3(t *testing.T) {
+	spec, err := loadSpec("testdata/openapi3.yaml", true)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+	defer spec.Cleanup()
+
+	if !spec.IsOpenAPI3() || spec.Version != "3.0.3" {
+		t.Errorf("expected OpenAPI 3.0.3 spec, got version %s", spec.Version)
+	}
+
+	if len(spec.OpenAPIJSON) == 0 {
+		t.Errorf("expected original OpenAPI document to be kept")
+	}
+
+	data, err := os.ReadFile(spec.Path)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	var doc openapi2.T
+	err = json.Unmarshal(data, &doc)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	if doc.Swagger != "2.0" {
+		t.Errorf("expected Swagger 2.0 document, got %s", doc.Swagger)
+	}
+
+	if doc.Info.Extensions[openAPIVersionExtension] != "3.0.3" {
+		t.Errorf("expected %s to be set, got %v", openAPIVersionExtension, doc.Info.Extensions)
+	}
+
+	createPet := doc.Paths["/pets"].Post
+	if len(createPet.Consumes) != 2 {
+		t.Errorf("expected request body media types as consumes, got %v", createPet.Consumes)
+	}
+
+	bearer := doc.SecurityDefinitions["Bearer"]
+	if bearer.Type != "apiKey" || bearer.Name != "Authorization" || bearer.Extensions[httpSchemeExtension] != "bearer" {
+		t.Errorf("unexpected bearer security definition: %#v", bearer)
+	}
+
+	oauth2 := doc.SecurityDefinitions["OAuth2"]
+	if oauth2.Type != "oauth2" || oauth2.Flow != "password" || oauth2.TokenURL == "" {
+		t.Errorf("unexpected oauth2 security definition: %#v", oauth2)
+	}
+
+	for _, tc := range []struct {
+		definition string
+		property   string
+		extension  string
+	}{
+		{definition: "Pet", extension: "x-oneOf"},
+		{definition: "Owner", property: "pet", extension: "x-anyOf"},
+	} {
+		schema := doc.Definitions[tc.definition].Value
+		if tc.property != "" {
+			schema = schema.Properties[tc.property].Value
+		}
+
+		alternatives, ok := schema.Extensions[tc.extension].([]interface{})
+		if !ok || len(alternatives) != 2 {
+			t.Errorf("expected %s with two alternatives on %s, got %v", tc.extension, tc.definition, schema.Extensions)
+		}
+	}
+}
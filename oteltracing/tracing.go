@@ -0,0 +1,88 @@
+package oteltracing
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	contextKey          = "otel_context"
+	instrumentationName = "github.com/mikkeloscar/gin-swagger/oteltracing"
+)
+
+// InitSpan initializes a server span named after the operation. The parent
+// span is extracted from the request headers with the propagator, e.g. the
+// W3C traceparent header. A nil propagator defaults to W3C trace context and
+// baggage.
+//
+// The span gets the HTTP semantic convention attributes of the request and
+// response. Errors added to the gin.Context are recorded on the span and the
+// span status is set to error for 5xx responses.
+func InitSpan(provider trace.TracerProvider, propagator propagation.TextMapPropagator, operationID string) gin.HandlerFunc {
+	tracer := provider.Tracer(instrumentationName)
+	if propagator == nil {
+		propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	}
+
+	return func(c *gin.Context) {
+		ctx := propagator.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		scheme := "http"
+		if c.Request.TLS != nil {
+			scheme = "https"
+		}
+
+		ctx, span := tracer.Start(ctx, operationID,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Request.Method),
+				semconv.HTTPRoute(c.FullPath()),
+				semconv.URLPath(c.Request.URL.Path),
+				semconv.URLScheme(scheme),
+				semconv.ClientAddress(c.ClientIP()),
+				semconv.UserAgentOriginal(c.Request.UserAgent()),
+			),
+		)
+		defer span.End()
+
+		c.Set(contextKey, ctx)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+
+		for _, err := range c.Errors {
+			span.RecordError(err.Err)
+		}
+
+		if status >= http.StatusInternalServerError {
+			span.SetAttributes(semconv.ErrorTypeKey.String(strconv.Itoa(status)))
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
+
+// Context returns the current tracing context for the request. If no span is
+// set on the gin.Context it will return the context of the request.
+func Context(c *gin.Context) context.Context {
+	value, ok := c.Get(contextKey)
+	if !ok {
+		return c.Request.Context()
+	}
+
+	ctx, ok := value.(context.Context)
+	if !ok {
+		return c.Request.Context()
+	}
+
+	return ctx
+}
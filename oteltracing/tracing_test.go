@@ -0,0 +1,109 @@
+package oteltracing
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestInitSpan(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	for _, tc := range []struct {
+		msg         string
+		traceparent string
+		statusCode  int
+		err         error
+		status      codes.Code
+	}{
+		{
+			msg:        "successful request",
+			statusCode: http.StatusOK,
+			status:     codes.Unset,
+		},
+		{
+			msg:         "parent span from traceparent header",
+			traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			statusCode:  http.StatusOK,
+			status:      codes.Unset,
+		},
+		{
+			msg:        "validation failure",
+			statusCode: http.StatusUnprocessableEntity,
+			err:        errors.New("invalid"),
+			status:     codes.Unset,
+		},
+		{
+			msg:        "server error",
+			statusCode: http.StatusInternalServerError,
+			err:        errors.New("failed"),
+			status:     codes.Error,
+		},
+	} {
+		t.Run(tc.msg, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+			router := gin.New()
+			router.Use(InitSpan(provider, nil, "getCluster"))
+			router.GET("/clusters/:id", func(c *gin.Context) {
+				if !trace.SpanContextFromContext(Context(c)).IsValid() {
+					t.Errorf("expected span in context")
+				}
+				if tc.err != nil {
+					_ = c.Error(tc.err)
+				}
+				c.Status(tc.statusCode)
+			})
+
+			req := httptest.NewRequest("GET", "/clusters/a", nil)
+			if tc.traceparent != "" {
+				req.Header.Set("traceparent", tc.traceparent)
+			}
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("expected one span, got %d", len(spans))
+			}
+			span := spans[0]
+
+			if span.Name() != "getCluster" || span.SpanKind() != trace.SpanKindServer {
+				t.Errorf("unexpected span %s of kind %s", span.Name(), span.SpanKind())
+			}
+
+			if tc.traceparent != "" && span.Parent().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+				t.Errorf("expected parent from traceparent header, got %s", span.Parent().TraceID())
+			}
+
+			attributes := map[string]interface{}{}
+			for _, attr := range span.Attributes() {
+				attributes[string(attr.Key)] = attr.Value.AsInterface()
+			}
+
+			if attributes[string(semconv.HTTPRouteKey)] != "/clusters/:id" {
+				t.Errorf("expected route attribute, got %v", attributes)
+			}
+
+			if attributes[string(semconv.HTTPResponseStatusCodeKey)] != int64(tc.statusCode) {
+				t.Errorf("expected status code attribute %d, got %v", tc.statusCode, attributes)
+			}
+
+			if span.Status().Code != tc.status {
+				t.Errorf("expected span status %s, got %s", tc.status, span.Status().Code)
+			}
+
+			if tc.err != nil && len(span.Events()) != 1 {
+				t.Errorf("expected recorded error, got %v", span.Events())
+			}
+		})
+	}
+}
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/imports"
+)
+
+// stubFileName is the name of the file the stubs of the missing Service
+// methods are added to.
+const stubFileName = "service_stub.go"
+
+// notImplementedResponses are the responses of an operation the stubs
+// respond with, in order of preference.
+var notImplementedResponses = []string{"NotImplemented", "Default", "InternalServerError"}
+
+// messageFields are the string fields of response payloads the stubs set
+// to the not implemented error, in order of preference.
+var messageFields = []string{"Message", "Detail", "Title", "Error"}
+
+// implementation is the type implementing the Service in a package.
+type implementation struct {
+	Package  string
+	Path     string
+	Receiver string
+	Pointer  bool
+	// Methods are the methods of the type, including promoted ones, with
+	// the type of their first param, if any.
+	Methods map[string]string
+}
+
+// writeServiceStubs adds stubs for the methods of the Service interface
+// in apiFile which typeName in dir lacks to the service_stub.go file of dir.
+// Existing methods are left alone, and methods of typeName which look like
+// operations but aren't part of the Service are reported.
+func writeServiceStubs(apiFile, dir, typeName string) error {
+	service, err := loadService(filepath.Dir(apiFile))
+	if err != nil {
+		return err
+	}
+
+	impl, err := loadImplementation(dir, typeName)
+	if err != nil {
+		return err
+	}
+
+	serviceMethods := make(map[string]bool, service.NumMethods())
+	var missing []*types.Func
+	for i := 0; i < service.NumMethods(); i++ {
+		method := service.Method(i)
+		serviceMethods[method.Name()] = true
+		if _, ok := impl.Methods[method.Name()]; !ok {
+			missing = append(missing, method)
+		}
+	}
+
+	for _, name := range sortedKeys(staleMethods(impl, serviceMethods)) {
+		log.Printf("%s.%s is not a method of the Service, its operation may have been removed from the spec", typeName, name)
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	stubFile := filepath.Join(dir, stubFileName)
+	src, err := os.ReadFile(stubFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		src = []byte("package " + impl.Package + "\n")
+	}
+
+	src, err = addStubs(stubFile, src, typeName, impl, missing)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(stubFile, src, 0o644)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Added stubs for %d missing Service methods to %s", len(missing), stubFile)
+	return nil
+}
+
+// loadPackage type checks the package in dir. Type errors are ignored, as
+// the package may lack methods of the Service until the stubs are added.
+func loadPackage(dir string) (*packages.Package, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo | packages.NeedImports,
+		Dir:  dir,
+	}
+
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pkgs) != 1 || pkgs[0].Types == nil {
+		return nil, fmt.Errorf("failed to load the package in %s", dir)
+	}
+	return pkgs[0], nil
+}
+
+// loadService returns the Service interface of the server package in dir.
+func loadService(dir string) (*types.Interface, error) {
+	pkg, err := loadPackage(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, ok := pkg.Types.Scope().Lookup("Service").(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("no Service interface found in %s", dir)
+	}
+
+	iface, ok := obj.Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil, fmt.Errorf("no Service interface found in %s", dir)
+	}
+	return iface, nil
+}
+
+// loadImplementation returns the methods of typeName in the package in
+// dir, including the methods promoted from embedded fields.
+func loadImplementation(dir, typeName string) (*implementation, error) {
+	pkg, err := loadPackage(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, ok := pkg.Types.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("type %s not found in %s", typeName, dir)
+	}
+
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("type %s in %s is not a named type", typeName, dir)
+	}
+
+	impl := &implementation{
+		Package: pkg.Types.Name(),
+		Path:    pkg.Types.Path(),
+		Pointer: true,
+		Methods: make(map[string]string),
+	}
+
+	// the receiver of the stubs is the one of the declared methods.
+	for i := 0; i < named.NumMethods(); i++ {
+		recv := named.Method(i).Type().(*types.Signature).Recv()
+		if recv.Name() != "" && recv.Name() != "_" {
+			_, impl.Pointer = recv.Type().(*types.Pointer)
+			impl.Receiver = recv.Name()
+			break
+		}
+	}
+
+	if impl.Receiver == "" {
+		impl.Receiver = strings.ToLower(typeName[:1])
+	}
+
+	methods := types.NewMethodSet(types.NewPointer(named))
+	for i := 0; i < methods.Len(); i++ {
+		fn := methods.At(i).Obj().(*types.Func)
+
+		var firstParam string
+		if params := fn.Type().(*types.Signature).Params(); params.Len() > 0 {
+			firstParam = types.TypeString(params.At(0).Type(), packageName)
+		}
+		impl.Methods[fn.Name()] = firstParam
+	}
+
+	return impl, nil
+}
+
+// packageName qualifies types by the name of their package.
+func packageName(pkg *types.Package) string {
+	return pkg.Name()
+}
+
+// staleMethods returns the exported methods of the implementation taking a
+// context as first param, like the Service methods, which aren't part of
+// the Service.
+func staleMethods(impl *implementation, serviceMethods map[string]bool) map[string]bool {
+	stale := make(map[string]bool)
+	for name, firstParam := range impl.Methods {
+		if serviceMethods[name] || !ast.IsExported(name) {
+			continue
+		}
+
+		if firstParam == "*gin.Context" || firstParam == "context.Context" {
+			stale[name] = true
+		}
+	}
+	return stale
+}
+
+// addStubs appends stubs of the methods to the src of the stub file and
+// adds the imports used by them. The stubs of operations respond with the
+// first of the notImplementedResponses of the operation, methods returning
+// an error return a not implemented error and other methods return zero
+// values.
+func addStubs(stubFile string, src []byte, typeName string, impl *implementation, methods []*types.Func) ([]byte, error) {
+	recvType := typeName
+	if impl.Pointer {
+		recvType = "*" + typeName
+	}
+
+	// the packages used by the stubs, by name.
+	used := make(map[string]string)
+	qualifier := func(pkg *types.Package) string {
+		if pkg.Path() == impl.Path {
+			return ""
+		}
+		used[pkg.Name()] = pkg.Path()
+		return pkg.Name()
+	}
+
+	buf := bytes.NewBuffer(src)
+	for _, method := range methods {
+		sig := method.Type().(*types.Signature)
+
+		fmt.Fprintf(buf, "\n// %s implements the Service.\n", method.Name())
+		fmt.Fprintf(buf, "func (%s %s) %s", impl.Receiver, recvType, method.Name())
+		types.WriteSignature(buf, sig, qualifier)
+		fmt.Fprintf(buf, " {\n\t%s\n}\n", stubBody(method.Name(), sig, qualifier))
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, stubFile, buf.Bytes(), parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range sortedKeys(mapKeys(used)) {
+		path := used[name]
+		if name == path[strings.LastIndex(path, "/")+1:] {
+			astutil.AddImport(fset, file, path)
+		} else {
+			astutil.AddNamedImport(fset, file, name, path)
+		}
+	}
+
+	// sort the imports added by astutil before removing the unused ones.
+	ast.SortImports(fset, file)
+
+	var out bytes.Buffer
+	err = printer.Fprint(&out, fset, file)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports.Process(stubFile, out.Bytes(), nil)
+}
+
+// mapKeys returns the keys of m as a set.
+func mapKeys(m map[string]string) map[string]bool {
+	keys := make(map[string]bool, len(m))
+	for key := range m {
+		keys[key] = true
+	}
+	return keys
+}
+
+// stubBody returns the body of the stub of the method.
+func stubBody(name string, sig *types.Signature, qualifier types.Qualifier) string {
+	message := name + " is not implemented"
+	results := sig.Results()
+
+	if results.Len() == 1 {
+		if responder, ok := results.At(0).Type().(*types.Named); ok && responder.Obj().Name() == name+"Responder" {
+			return responseBody(name, responder.Obj().Pkg(), message, qualifier)
+		}
+	}
+
+	values := make([]string, 0, results.Len())
+	for i := 0; i < results.Len(); i++ {
+		t := results.At(i).Type()
+		if i == results.Len()-1 && types.Identical(t, types.Universe.Lookup("error").Type()) {
+			values = append(values, "errors.New("+strconv.Quote(message)+")")
+			continue
+		}
+		values = append(values, zeroValue(t, qualifier))
+	}
+
+	return strings.TrimSpace("return " + strings.Join(values, ", "))
+}
+
+// responseBody returns the body of the stub of an operation responding
+// with the first of the notImplementedResponses found in the package of its
+// Responder, or nil, which is responded to with a 500, if there is none.
+func responseBody(name string, pkg *types.Package, message string, qualifier types.Qualifier) string {
+	for _, response := range notImplementedResponses {
+		constructor, ok := pkg.Scope().Lookup("New" + name + response).(*types.Func)
+		if !ok {
+			continue
+		}
+
+		params := constructor.Type().(*types.Signature).Params()
+		args := make([]string, 0, params.Len())
+		for i := 0; i < params.Len(); i++ {
+			args = append(args, argument(params.At(i).Type(), message, qualifier))
+		}
+		return fmt.Sprintf("return %s.%s(%s)", qualifier(pkg), constructor.Name(), strings.Join(args, ", "))
+	}
+
+	return "return nil"
+}
+
+// argument returns the argument of a param of a response constructor: 501
+// for the status code and the message for strings, interfaces and the
+// messageFields of payloads.
+func argument(t types.Type, message string, qualifier types.Qualifier) string {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsInteger != 0:
+			return "http.StatusNotImplemented"
+		case u.Info()&types.IsString != 0:
+			return strconv.Quote(message)
+		}
+	case *types.Interface:
+		if u.Empty() {
+			return strconv.Quote(message)
+		}
+	case *types.Struct:
+		return types.TypeString(t, qualifier) + "{" + messageField(u, message) + "}"
+	case *types.Pointer:
+		if st, ok := u.Elem().Underlying().(*types.Struct); ok {
+			return "&" + types.TypeString(u.Elem(), qualifier) + "{" + messageField(st, message) + "}"
+		}
+	}
+	return zeroValue(t, qualifier)
+}
+
+// messageField returns the first of the messageFields of the struct set to
+// the message, e.g. "Message: ...", or an empty string if it has none.
+func messageField(st *types.Struct, message string) string {
+	for _, name := range messageFields {
+		for i := 0; i < st.NumFields(); i++ {
+			field := st.Field(i)
+			if field.Name() == name && types.Identical(field.Type(), types.Typ[types.String]) {
+				return name + ": " + strconv.Quote(message)
+			}
+		}
+	}
+	return ""
+}
+
+// zeroValue returns the zero value of the type.
+func zeroValue(t types.Type, qualifier types.Qualifier) string {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return "false"
+		case u.Info()&types.IsNumeric != 0:
+			return "0"
+		case u.Info()&types.IsString != 0:
+			return `""`
+		}
+	case *types.Struct, *types.Array:
+		return types.TypeString(t, qualifier) + "{}"
+	}
+	return "nil"
+}
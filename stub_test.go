@@ -0,0 +1,202 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const stubAPI = `package restapi
+
+import (
+	"context"
+
+	"github.com/org/repo/restapi/operations/pets"
+)
+
+type Service interface {
+	Healthy() bool
+	GetPet(ctx context.Context, params *pets.GetPetParams) pets.GetPetResponder
+	// UpdatePetETag returns the current entity tag of the resource
+	// modified by UpdatePet, or an empty string if it doesn't exist.
+	UpdatePetETag(ctx context.Context, params *pets.UpdatePetParams) (string, error)
+	UpdatePet(ctx context.Context, params *pets.UpdatePetParams) pets.UpdatePetResponder
+	DeletePet(ctx context.Context, params *pets.DeletePetParams) pets.DeletePetResponder
+	ListPets(ctx context.Context) pets.ListPetsResponder
+}
+`
+
+const stubResponses = `package pets
+
+import "github.com/org/repo/models"
+
+type GetPetParams struct{}
+
+type UpdatePetParams struct{}
+
+type DeletePetParams struct{}
+
+type GetPetResponder interface{ getPetResponse() }
+
+type UpdatePetResponder interface{ updatePetResponse() }
+
+type DeletePetResponder interface{ deletePetResponse() }
+
+type ListPetsResponder interface{ listPetsResponse() }
+
+type GetPetNotFound struct{}
+
+func (*GetPetNotFound) getPetResponse() {}
+
+func NewGetPetNotFound() *GetPetNotFound { return nil }
+
+type GetPetDefault struct{}
+
+func (*GetPetDefault) getPetResponse() {}
+
+func NewGetPetDefault(code int, payload *models.Error) *GetPetDefault { return nil }
+
+type UpdatePetInternalServerError struct{}
+
+func (*UpdatePetInternalServerError) updatePetResponse() {}
+
+func NewUpdatePetInternalServerError(payload Message) *UpdatePetInternalServerError { return nil }
+
+type DeletePetNoContent struct{}
+
+func (*DeletePetNoContent) deletePetResponse() {}
+
+func NewDeletePetNoContent() *DeletePetNoContent { return nil }
+
+type Message string
+`
+
+const stubModels = `package models
+
+type Error struct {
+	Code    int32
+	Message string
+}
+`
+
+// stubService gets ListPets promoted from the embedded lister.
+const stubService = `package main
+
+import (
+	"context"
+
+	"github.com/org/repo/restapi/operations/pets"
+)
+
+type lister struct{}
+
+func (lister) ListPets(ctx context.Context) pets.ListPetsResponder { return nil }
+
+type svc struct {
+	lister
+}
+
+func (p *svc) GetHealth(ctx context.Context) {}
+
+func main() {}
+`
+
+func TestWriteServiceStubs(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module github.com/org/repo\n\ngo 1.21\n")
+	apiFile := filepath.Join(dir, "restapi", "api.go")
+	writeFile(t, apiFile, stubAPI)
+	writeFile(t, filepath.Join(dir, "restapi", "operations", "pets", "responses.go"), stubResponses)
+	writeFile(t, filepath.Join(dir, "models", "error.go"), stubModels)
+	writeFile(t, filepath.Join(dir, "service.go"), stubService)
+
+	err := writeServiceStubs(apiFile, dir, "svc")
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	expected := `package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/org/repo/models"
+	"github.com/org/repo/restapi/operations/pets"
+)
+
+// DeletePet implements the Service.
+func (p *svc) DeletePet(ctx context.Context, params *pets.DeletePetParams) pets.DeletePetResponder {
+	return nil
+}
+
+// GetPet implements the Service.
+func (p *svc) GetPet(ctx context.Context, params *pets.GetPetParams) pets.GetPetResponder {
+	return pets.NewGetPetDefault(http.StatusNotImplemented, &models.Error{Message: "GetPet is not implemented"})
+}
+
+// Healthy implements the Service.
+func (p *svc) Healthy() bool {
+	return false
+}
+
+// UpdatePet implements the Service.
+func (p *svc) UpdatePet(ctx context.Context, params *pets.UpdatePetParams) pets.UpdatePetResponder {
+	return pets.NewUpdatePetInternalServerError("UpdatePet is not implemented")
+}
+
+// UpdatePetETag implements the Service.
+func (p *svc) UpdatePetETag(ctx context.Context, params *pets.UpdatePetParams) (string, error) {
+	return "", errors.New("UpdatePetETag is not implemented")
+}
+`
+	stub, err := os.ReadFile(filepath.Join(dir, stubFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(stub) != expected {
+		t.Errorf("expected stubs:\n%s\ngot:\n%s", expected, stub)
+	}
+
+	// the existing stubs are kept.
+	err = writeServiceStubs(apiFile, dir, "svc")
+	if err != nil {
+		t.Fatalf("should not fail: %s", err)
+	}
+
+	stub, err = os.ReadFile(filepath.Join(dir, stubFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Count(string(stub), "func ") != 5 {
+		t.Errorf("expected stubs to be kept, got:\n%s", stub)
+	}
+
+	err = writeServiceStubs(apiFile, dir, "missing")
+	if err == nil {
+		t.Error("expected error for missing type")
+	}
+}
+
+func TestStaleMethods(t *testing.T) {
+	impl := &implementation{
+		Methods: map[string]string{
+			"Healthy":   "",
+			"ListPets":  "*gin.Context",
+			"GetHealth": "*gin.Context",
+			"GetOwner":  "context.Context",
+			"Close":     "",
+			"getPet":    "*gin.Context",
+		},
+	}
+
+	stale := staleMethods(impl, map[string]bool{"Healthy": true, "ListPets": true})
+	expected := []string{"GetHealth", "GetOwner"}
+	if got := sortedKeys(stale); strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected stale methods %v, got %v", expected, got)
+	}
+}